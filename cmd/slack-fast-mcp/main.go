@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/kai-kou/slack-fast-mcp/internal/cli"
@@ -37,6 +38,9 @@ func main() {
 		// AppError の場合は詳細なエラーメッセージを表示
 		if appErr, ok := err.(*apperr.AppError); ok {
 			fmt.Fprintf(os.Stderr, "\n❌ Error [%s]: %s\n", appErr.Code, appErr.Message)
+			if len(appErr.Suggestions) > 0 {
+				fmt.Fprintf(os.Stderr, "🔎 Did you mean: %s\n", strings.Join(appErr.Suggestions, ", "))
+			}
 			if appErr.Hint != "" {
 				fmt.Fprintf(os.Stderr, "💡 %s\n\n", appErr.Hint)
 			}
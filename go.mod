@@ -5,6 +5,8 @@ go 1.25.0
 require (
 	github.com/mark3labs/mcp-go v0.43.2
 	github.com/slack-go/slack v0.17.3
+	github.com/spf13/cobra v1.10.2
+	golang.org/x/term v0.40.0
 )
 
 require (
@@ -16,11 +18,9 @@ require (
 	github.com/invopop/jsonschema v0.13.0 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/spf13/cast v1.7.1 // indirect
-	github.com/spf13/cobra v1.10.2 // indirect
 	github.com/spf13/pflag v1.0.9 // indirect
 	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 	golang.org/x/sys v0.41.0 // indirect
-	golang.org/x/term v0.40.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,365 @@
+// Package cache provides a persistent on-disk cache of channel and user names per Slack workspace.
+package cache
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTTL はキャッシュエントリのデフォルト有効期間。
+	DefaultTTL = 24 * time.Hour
+
+	// EnvCacheDir はキャッシュディレクトリを上書きする環境変数名。
+	EnvCacheDir = "SLACK_FAST_MCP_CACHE_DIR"
+
+	// cacheDirName はユーザーキャッシュディレクトリ配下のサブディレクトリ名。
+	cacheDirName = "slack-fast-mcp"
+
+	// lockTimeout はロック取得の最大待ち時間。
+	lockTimeout = 3 * time.Second
+
+	// staleLockAge はこれより古いロックファイルを異常終了したプロセスの残骸とみなす時間。
+	staleLockAge = 30 * time.Second
+)
+
+// Entry はキャッシュされた値1件。
+type Entry struct {
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Workspace はワークスペース（チーム）1つ分のキャッシュ。
+type Workspace struct {
+	TeamID   string           `json:"team_id"`
+	Channels map[string]Entry `json:"channels"` // チャンネル名 → ID
+	Users    map[string]Entry `json:"users"`    // ユーザーID → ユーザー名
+}
+
+// Store はワークスペースごとの JSON ファイルでキャッシュを永続化する。
+// 書き込みはロックファイルで排他し、一時ファイルからの rename で置き換えるため、
+// 複数プロセス（CLI と MCP Server 等）から同時に利用できる。
+type Store struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// DefaultDir はキャッシュディレクトリを返す。
+// SLACK_FAST_MCP_CACHE_DIR が設定されていればそれを、なければユーザーキャッシュディレクトリ配下を使う。
+func DefaultDir() (string, error) {
+	if dir := os.Getenv(EnvCacheDir); dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, cacheDirName), nil
+}
+
+// New は dir 配下にキャッシュを保存する Store を作成する。
+func New(dir string, ttl time.Duration) *Store {
+	return &Store{dir: dir, ttl: ttl, now: time.Now}
+}
+
+// Open はデフォルトのキャッシュディレクトリで Store を作成する。
+// ttl が 0 以下（キャッシュ無効）またはディレクトリを特定できない場合は nil を返す。
+func Open(ttl time.Duration) *Store {
+	if ttl <= 0 {
+		return nil
+	}
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil
+	}
+	return New(dir, ttl)
+}
+
+// Dir はキャッシュディレクトリを返す。
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Channel はキャッシュ済みのチャンネルIDを返す。
+func (s *Store) Channel(teamID, name string) (string, bool) {
+	w, err := s.Load(teamID)
+	if err != nil {
+		return "", false
+	}
+	return s.lookup(w.Channels, name)
+}
+
+// Users はキャッシュ済みのユーザー名を返す（見つかったIDのみ）。
+func (s *Store) Users(teamID string, ids []string) map[string]string {
+	names := make(map[string]string)
+	w, err := s.Load(teamID)
+	if err != nil {
+		return names
+	}
+	for _, id := range ids {
+		if name, ok := s.lookup(w.Users, id); ok {
+			names[id] = name
+		}
+	}
+	return names
+}
+
+// lookup は有効期限内のエントリを返す。
+func (s *Store) lookup(entries map[string]Entry, key string) (string, bool) {
+	e, ok := entries[key]
+	if !ok || s.expired(e) {
+		return "", false
+	}
+	return e.Value, true
+}
+
+// expired はエントリが TTL を過ぎているかどうかを判定する。
+func (s *Store) expired(e Entry) bool {
+	return s.now().Sub(e.UpdatedAt) > s.ttl
+}
+
+// SetChannels はチャンネル名 → ID を保存する。
+func (s *Store) SetChannels(teamID string, channels map[string]string) error {
+	return s.update(teamID, func(w *Workspace) {
+		for name, id := range channels {
+			w.Channels[name] = Entry{Value: id, UpdatedAt: s.now()}
+		}
+	})
+}
+
+// SetUsers はユーザーID → ユーザー名を保存する。
+func (s *Store) SetUsers(teamID string, users map[string]string) error {
+	return s.update(teamID, func(w *Workspace) {
+		for id, name := range users {
+			w.Users[id] = Entry{Value: name, UpdatedAt: s.now()}
+		}
+	})
+}
+
+// DeleteChannel はチャンネル名のエントリを削除する（channel_not_found 時の無効化用）。
+func (s *Store) DeleteChannel(teamID, name string) error {
+	return s.update(teamID, func(w *Workspace) {
+		delete(w.Channels, name)
+	})
+}
+
+// Load はワークスペースのキャッシュを読み込む（期限切れのエントリも含む）。
+// ファイルが存在しない場合は空のキャッシュを返す。
+func (s *Store) Load(teamID string) (*Workspace, error) {
+	path, err := s.path(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{TeamID: teamID}
+	data, err := os.ReadFile(path)
+	if err == nil {
+		// 壊れたファイルは空のキャッシュとして扱い、次回の書き込みで置き換える
+		_ = json.Unmarshal(data, w)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	if w.Channels == nil {
+		w.Channels = make(map[string]Entry)
+	}
+	if w.Users == nil {
+		w.Users = make(map[string]Entry)
+	}
+	return w, nil
+}
+
+// Workspaces はキャッシュされている全ワークスペースを team ID 順に返す。
+func (s *Store) Workspaces() ([]*Workspace, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var out []*Workspace
+	for _, f := range files {
+		w, err := s.Load(strings.TrimSuffix(filepath.Base(f), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, nil
+}
+
+// Expired はエントリが期限切れかどうかを返す（表示用）。
+func (s *Store) Expired(e Entry) bool {
+	return s.expired(e)
+}
+
+// Clear は teamID のキャッシュを削除する。teamID が空の場合は全ワークスペースを削除する。
+// 削除したワークスペース数を返す。
+func (s *Store) Clear(teamID string) (int, error) {
+	var teams []string
+	if teamID != "" {
+		teams = []string{teamID}
+	} else {
+		ws, err := s.Workspaces()
+		if err != nil {
+			return 0, err
+		}
+		for _, w := range ws {
+			teams = append(teams, w.TeamID)
+		}
+	}
+
+	removed := 0
+	for _, team := range teams {
+		path, err := s.path(team)
+		if err != nil {
+			return removed, err
+		}
+		err = withFileLock(path, func() error {
+			return os.Remove(path)
+		})
+		if err == nil {
+			removed++
+		} else if !os.IsNotExist(err) {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// update はロックを取得してキャッシュを読み込み、fn で変更して書き戻す。
+// 期限切れのエントリは書き込み時に取り除く。
+func (s *Store) update(teamID string, fn func(w *Workspace)) error {
+	path, err := s.path(teamID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+
+	return withFileLock(path, func() error {
+		w, err := s.Load(teamID)
+		if err != nil {
+			return err
+		}
+		fn(w)
+		for k, e := range w.Channels {
+			if s.expired(e) {
+				delete(w.Channels, k)
+			}
+		}
+		for k, e := range w.Users {
+			if s.expired(e) {
+				delete(w.Users, k)
+			}
+		}
+		return writeFileAtomic(path, w)
+	})
+}
+
+// path はワークスペースのキャッシュファイルパスを返す。
+func (s *Store) path(teamID string) (string, error) {
+	return teamFile(s.dir, teamID)
+}
+
+// teamFile は dir 配下のワークスペースごとの JSON ファイルパスを返す。
+func teamFile(dir, teamID string) (string, error) {
+	if teamID == "" || strings.ContainsAny(teamID, `/\.`) {
+		return "", fmt.Errorf("invalid team id: %q", teamID)
+	}
+	return filepath.Join(dir, teamID+".json"), nil
+}
+
+// withFileLock は path.lock を排他的に作成してから fn を実行する。
+// flock 等の OS 固有 API を使わず、O_EXCL による作成でロックする（Windows でも動作する）。
+// ロックファイルには取得ごとのトークンを書き込み、解放時・古いロックの削除時は中身を確かめてから消す
+// （他のプロセスが取り直したロックを消さない）。
+func withFileLock(path string, fn func() error) error {
+	lock := path + ".lock"
+	token := rand.Text()
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, err = f.WriteString(token)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(lock)
+				return err
+			}
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return err
+		}
+		// 異常終了したプロセスのロックは、その中身のロックであることを確かめて削除してから取り直す
+		if info, statErr := os.Stat(lock); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			if stale, readErr := os.ReadFile(lock); readErr == nil && removeLockIf(lock, string(stale)) {
+				continue
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for cache lock %s", lock)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer removeLockIf(lock, token)
+
+	return fn()
+}
+
+// removeLockIf はロックファイル lock の中身が token の場合のみ削除し、削除したかどうかを返す。
+// 確認と削除の間に別のプロセスがロックを取り直しても消さないよう、rename で退避してから中身を確かめ、
+// 別のロックだった場合は（その間に新しいロックが作られていなければ）元に戻す。
+func removeLockIf(lock, token string) bool {
+	moved := lock + "." + rand.Text()
+	if err := os.Rename(lock, moved); err != nil {
+		return false
+	}
+	if data, err := os.ReadFile(moved); err == nil && string(data) == token {
+		os.Remove(moved)
+		return true
+	}
+	// os.Link は lock が存在する場合は失敗するため、新しいロックを上書きしない
+	os.Link(moved, lock)
+	os.Remove(moved)
+	return false
+}
+
+// writeFileAtomic は v を JSON で一時ファイルに書き込んでから rename で置き換える。
+// 読み込み側はロックなしでも書きかけのファイルを読むことがない。
+func writeFileAtomic(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
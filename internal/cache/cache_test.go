@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T, ttl time.Duration) *Store {
+	t.Helper()
+	return New(t.TempDir(), ttl)
+}
+
+func TestStore_ChannelsAndUsers(t *testing.T) {
+	s := newTestStore(t, time.Hour)
+
+	if _, ok := s.Channel("T01234ABCDE", "general"); ok {
+		t.Fatal("empty cache should miss")
+	}
+
+	if err := s.SetChannels("T01234ABCDE", map[string]string{"general": "C01234ABCDE"}); err != nil {
+		t.Fatalf("SetChannels: %v", err)
+	}
+	if err := s.SetUsers("T01234ABCDE", map[string]string{"U01": "alice"}); err != nil {
+		t.Fatalf("SetUsers: %v", err)
+	}
+
+	if id, ok := s.Channel("T01234ABCDE", "general"); !ok || id != "C01234ABCDE" {
+		t.Errorf("Channel = %q, %v; want C01234ABCDE", id, ok)
+	}
+	if users := s.Users("T01234ABCDE", []string{"U01", "U02"}); len(users) != 1 || users["U01"] != "alice" {
+		t.Errorf("Users = %v, want only U01", users)
+	}
+
+	// ワークスペースごとに分離される
+	if _, ok := s.Channel("T99999ZZZZZ", "general"); ok {
+		t.Error("other workspace should miss")
+	}
+
+	if err := s.DeleteChannel("T01234ABCDE", "general"); err != nil {
+		t.Fatalf("DeleteChannel: %v", err)
+	}
+	if _, ok := s.Channel("T01234ABCDE", "general"); ok {
+		t.Error("deleted channel should miss")
+	}
+}
+
+func TestStore_TTL(t *testing.T) {
+	s := newTestStore(t, time.Hour)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	s.SetChannels("T01234ABCDE", map[string]string{"general": "C01234ABCDE"})
+
+	now = now.Add(59 * time.Minute)
+	if _, ok := s.Channel("T01234ABCDE", "general"); !ok {
+		t.Error("entry within TTL should hit")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := s.Channel("T01234ABCDE", "general"); ok {
+		t.Error("expired entry should miss")
+	}
+
+	// 期限切れのエントリは次の書き込みで取り除かれる
+	s.SetChannels("T01234ABCDE", map[string]string{"random": "C09876ZZZZZ"})
+	w, _ := s.Load("T01234ABCDE")
+	if _, ok := w.Channels["general"]; ok || len(w.Channels) != 1 {
+		t.Errorf("Channels = %v, want expired entry pruned", w.Channels)
+	}
+}
+
+func TestStore_ConcurrentWriters(t *testing.T) {
+	dir := t.TempDir()
+
+	// 別プロセスを模して Store を個別に作成する
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s := New(dir, time.Hour)
+			if err := s.SetChannels("T01234ABCDE", map[string]string{fmt.Sprintf("ch-%02d", i): fmt.Sprintf("C%010d", i)}); err != nil {
+				t.Errorf("SetChannels: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	w, err := New(dir, time.Hour).Load("T01234ABCDE")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(w.Channels) != 20 {
+		t.Errorf("Channels = %d, want 20 (no lost updates)", len(w.Channels))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "T01234ABCDE.json.lock")); !os.IsNotExist(err) {
+		t.Error("lock file should be removed")
+	}
+}
+
+func TestStore_StaleLock(t *testing.T) {
+	s := newTestStore(t, time.Hour)
+	lock := filepath.Join(s.Dir(), "T01234ABCDE.json.lock")
+	if err := os.WriteFile(lock, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Minute)
+	os.Chtimes(lock, old, old)
+
+	if err := s.SetChannels("T01234ABCDE", map[string]string{"general": "C01234ABCDE"}); err != nil {
+		t.Fatalf("SetChannels with stale lock: %v", err)
+	}
+	if _, err := os.Stat(lock); !os.IsNotExist(err) {
+		t.Error("lock file should be removed after the stale lock is taken over")
+	}
+}
+
+func TestWithFileLock_KeepsOtherHoldersLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "T01234ABCDE.json")
+	lock := path + ".lock"
+	// 保持中にロックが別のプロセスに取り直された場合、解放時にそのロックを消さない
+	err := withFileLock(path, func() error {
+		return os.WriteFile(lock, []byte("other-holder"), 0o600)
+	})
+	if err != nil {
+		t.Fatalf("withFileLock() error = %v", err)
+	}
+	if data, err := os.ReadFile(lock); err != nil || string(data) != "other-holder" {
+		t.Errorf("lock = %q, %v; want the other holder's lock kept", data, err)
+	}
+
+	// 古いと判断した後に取り直されたロックは削除しない
+	if removeLockIf(lock, "stale-holder") {
+		t.Error("removeLockIf() removed a lock with a different token")
+	}
+	if data, err := os.ReadFile(lock); err != nil || string(data) != "other-holder" {
+		t.Errorf("lock = %q, %v; want it restored", data, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("dir entries = %d, want only the lock file", len(entries))
+	}
+}
+
+func TestStore_CorruptFile(t *testing.T) {
+	s := newTestStore(t, time.Hour)
+	if err := os.WriteFile(filepath.Join(s.Dir(), "T01234ABCDE.json"), []byte("{broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := s.Channel("T01234ABCDE", "general"); ok {
+		t.Error("corrupt cache should miss")
+	}
+	if err := s.SetChannels("T01234ABCDE", map[string]string{"general": "C01234ABCDE"}); err != nil {
+		t.Fatalf("SetChannels should replace corrupt file: %v", err)
+	}
+	if _, ok := s.Channel("T01234ABCDE", "general"); !ok {
+		t.Error("entry should hit after rewrite")
+	}
+}
+
+func TestStore_WorkspacesAndClear(t *testing.T) {
+	s := newTestStore(t, time.Hour)
+	s.SetChannels("T22222BBBBB", map[string]string{"dev": "C22222BBBBB"})
+	s.SetChannels("T11111AAAAA", map[string]string{"general": "C11111AAAAA"})
+
+	ws, err := s.Workspaces()
+	if err != nil {
+		t.Fatalf("Workspaces: %v", err)
+	}
+	if len(ws) != 2 || ws[0].TeamID != "T11111AAAAA" || ws[1].TeamID != "T22222BBBBB" {
+		t.Fatalf("Workspaces = %+v, want 2 sorted by team ID", ws)
+	}
+
+	if n, err := s.Clear("T11111AAAAA"); err != nil || n != 1 {
+		t.Errorf("Clear(team) = %d, %v; want 1", n, err)
+	}
+	if n, err := s.Clear("T00000NONE0"); err != nil || n != 0 {
+		t.Errorf("Clear(unknown) = %d, %v; want 0", n, err)
+	}
+	if n, err := s.Clear(""); err != nil || n != 1 {
+		t.Errorf("Clear(all) = %d, %v; want 1", n, err)
+	}
+	if ws, _ := s.Workspaces(); len(ws) != 0 {
+		t.Errorf("Workspaces after clear = %d, want 0", len(ws))
+	}
+}
+
+func TestStore_InvalidTeamID(t *testing.T) {
+	s := newTestStore(t, time.Hour)
+	if err := s.SetChannels("../etc", map[string]string{"a": "b"}); err == nil {
+		t.Error("expected error for path-like team id")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	t.Setenv(EnvCacheDir, t.TempDir())
+	if Open(0) != nil {
+		t.Error("Open(0) should disable the cache")
+	}
+	if s := Open(time.Hour); s == nil || s.Dir() != os.Getenv(EnvCacheDir) {
+		t.Errorf("Open(1h) = %v, want store in %s", s, os.Getenv(EnvCacheDir))
+	}
+}
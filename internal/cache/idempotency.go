@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultIdempotencyWindow は idempotency_key を記憶するデフォルトの期間。
+	DefaultIdempotencyWindow = time.Hour
+
+	// MaxIdempotencyKeys はワークスペースごとに記憶する idempotency_key の上限。
+	// 超えた場合は古いものから削除する。
+	MaxIdempotencyKeys = 1000
+
+	// PendingIdempotencyAge は投稿中（Pending）の記録を有効とみなす時間。
+	// これより古い記録は投稿中に異常終了したプロセスの残骸とみなし、同じキーでの再投稿を許す。
+	PendingIdempotencyAge = 5 * time.Minute
+
+	// idempotencyDirName はキャッシュディレクトリ配下の idempotency_key 保存先。
+	idempotencyDirName = "idempotency"
+)
+
+// IdempotencyEntry は idempotency_key 1件分の記録。
+type IdempotencyEntry struct {
+	// Fingerprint は投稿内容（チャンネル・本文等）のハッシュ。同じキーで異なる内容が送られたことの検出に使う。
+	Fingerprint string `json:"fingerprint"`
+	// Result は最初の投稿結果（JSON）。
+	Result json.RawMessage `json:"result"`
+	// CreatedAt は最初に投稿した時刻（Pending の場合は投稿を始めた時刻）。
+	CreatedAt time.Time `json:"created_at"`
+	// Pending は投稿中（Reserve 済みで結果が未保存）であることを示す。
+	Pending bool `json:"pending,omitempty"`
+}
+
+// IdempotencyStore は idempotency_key → 投稿結果をワークスペースごとに期間・件数を限って保存する。
+// ディレクトリを指定した場合はワークスペースごとの JSON ファイルに永続化し、
+// 複数プロセス（CLI の再実行等）の間でも重複投稿を防ぐ（Reserve でキーを予約してから投稿する）。
+// 空の場合はメモリ上にのみ保存する。
+type IdempotencyStore struct {
+	dir     string
+	window  time.Duration
+	maxKeys int
+	now     func() time.Time
+
+	mu  sync.Mutex
+	mem map[string]map[string]IdempotencyEntry // チームID → キー → 記録（dir が空の場合のみ使用）
+}
+
+// NewIdempotencyStore は dir 配下に idempotency_key を保存する IdempotencyStore を作成する。
+// dir が空の場合はメモリ上にのみ保存する。
+func NewIdempotencyStore(dir string, window time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		dir:     dir,
+		window:  window,
+		maxKeys: MaxIdempotencyKeys,
+		now:     time.Now,
+		mem:     make(map[string]map[string]IdempotencyEntry),
+	}
+}
+
+// OpenIdempotency はデフォルトのキャッシュディレクトリ配下で IdempotencyStore を作成する。
+// window が 0 以下（無効）の場合は nil を返す。ディレクトリを特定できない場合はメモリ上にのみ保存する。
+func OpenIdempotency(window time.Duration) *IdempotencyStore {
+	if window <= 0 {
+		return nil
+	}
+	dir, err := DefaultDir()
+	if err != nil {
+		return NewIdempotencyStore("", window)
+	}
+	return NewIdempotencyStore(filepath.Join(dir, idempotencyDirName), window)
+}
+
+// Reserve はキーが未使用の場合に投稿中の記録（Pending）を保存して true を返す。
+// 有効期間内の記録（投稿済み、または他の呼び出しが投稿中）がある場合は保存せず、その記録と false を返す。
+// 確認と保存は同じロック内で行うため、複数プロセスから同時に呼び出しても true を返すのは1つだけになる。
+func (s *IdempotencyStore) Reserve(teamID, key, fingerprint string) (IdempotencyEntry, bool, error) {
+	var existing IdempotencyEntry
+	reserved := false
+	err := s.update(teamID, func(entries map[string]IdempotencyEntry) {
+		if e, ok := entries[key]; ok && !s.expired(e) {
+			existing = e
+			return
+		}
+		entries[key] = IdempotencyEntry{Fingerprint: fingerprint, CreatedAt: s.now(), Pending: true}
+		reserved = true
+	})
+	if err != nil {
+		return IdempotencyEntry{}, false, err
+	}
+	return existing, reserved, nil
+}
+
+// Release は投稿中の記録を削除する（投稿に失敗した場合に、同じキーで再投稿できるようにする）。
+func (s *IdempotencyStore) Release(teamID, key string) error {
+	return s.update(teamID, func(entries map[string]IdempotencyEntry) {
+		if e, ok := entries[key]; ok && e.Pending {
+			delete(entries, key)
+		}
+	})
+}
+
+// Put は記録を保存する。期限切れの記録は取り除き、上限を超えた場合は古いものから削除する。
+func (s *IdempotencyStore) Put(teamID, key string, e IdempotencyEntry) error {
+	return s.update(teamID, func(entries map[string]IdempotencyEntry) {
+		entries[key] = e
+	})
+}
+
+// update はワークスペースの記録を fn で変更して保存する。期限切れの記録は取り除き、上限を超えた分は古いものから削除する。
+// 読み込みから保存までをロック内で行う（ディスクの場合はファイルロックで他のプロセスとも排他する）。
+func (s *IdempotencyStore) update(teamID string, fn func(entries map[string]IdempotencyEntry)) error {
+	if s.dir == "" {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		entries := s.mem[teamID]
+		if entries == nil {
+			entries = make(map[string]IdempotencyEntry)
+			s.mem[teamID] = entries
+		}
+		fn(entries)
+		s.prune(entries)
+		return nil
+	}
+
+	path, err := teamFile(s.dir, teamID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	return withFileLock(path, func() error {
+		entries, err := s.load(teamID)
+		if err != nil {
+			return err
+		}
+		fn(entries)
+		s.prune(entries)
+		return writeFileAtomic(path, entries)
+	})
+}
+
+// load はワークスペースの記録を読み込む（期限切れの記録も含む）。
+func (s *IdempotencyStore) load(teamID string) (map[string]IdempotencyEntry, error) {
+	if s.dir == "" {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		entries := make(map[string]IdempotencyEntry, len(s.mem[teamID]))
+		for k, e := range s.mem[teamID] {
+			entries[k] = e
+		}
+		return entries, nil
+	}
+
+	path, err := teamFile(s.dir, teamID)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]IdempotencyEntry)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		// 壊れたファイルは空として扱い、次回の書き込みで置き換える
+		_ = json.Unmarshal(data, &entries)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// prune は期限切れの記録を削除し、上限を超えた分を古いものから削除する。
+func (s *IdempotencyStore) prune(entries map[string]IdempotencyEntry) {
+	for k, e := range entries {
+		if s.expired(e) {
+			delete(entries, k)
+		}
+	}
+	if len(entries) <= s.maxKeys {
+		return
+	}
+
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return entries[keys[i]].CreatedAt.Before(entries[keys[j]].CreatedAt)
+	})
+	for _, k := range keys[:len(keys)-s.maxKeys] {
+		delete(entries, k)
+	}
+}
+
+// expired は記録が有効期間を過ぎているかどうかを判定する。投稿中の記録は PendingIdempotencyAge を過ぎると期限切れになる。
+func (s *IdempotencyStore) expired(e IdempotencyEntry) bool {
+	age := s.now().Sub(e.CreatedAt)
+	if e.Pending {
+		return age > min(s.window, PendingIdempotencyAge)
+	}
+	return age > s.window
+}
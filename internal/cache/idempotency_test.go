@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// getEntry は有効期間内の記録を返す（テスト用）。
+func getEntry(s *IdempotencyStore, teamID, key string) (IdempotencyEntry, bool) {
+	entries, err := s.load(teamID)
+	if err != nil {
+		return IdempotencyEntry{}, false
+	}
+	e, ok := entries[key]
+	if !ok || s.expired(e) {
+		return IdempotencyEntry{}, false
+	}
+	return e, true
+}
+
+func TestIdempotencyStore_Put(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		dir  string
+	}{
+		{"disk", t.TempDir()},
+		{"memory", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewIdempotencyStore(tt.dir, time.Hour)
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			s.now = func() time.Time { return now }
+
+			if _, ok := getEntry(s, "T01234ABCDE", "deploy-42"); ok {
+				t.Fatal("empty store should miss")
+			}
+			entry := IdempotencyEntry{Fingerprint: "abc", Result: json.RawMessage(`{"ts":"1.2"}`), CreatedAt: now}
+			if err := s.Put("T01234ABCDE", "deploy-42", entry); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+
+			got, ok := getEntry(s, "T01234ABCDE", "deploy-42")
+			var result map[string]string
+			if !ok || got.Fingerprint != "abc" || json.Unmarshal(got.Result, &result) != nil || result["ts"] != "1.2" {
+				t.Errorf("Get = %+v, %v", got, ok)
+			}
+			// ワークスペースごとに分離される
+			if _, ok := getEntry(s, "T99999ZZZZZ", "deploy-42"); ok {
+				t.Error("other workspace should miss")
+			}
+			// 期間を過ぎた記録は返さない
+			now = now.Add(61 * time.Minute)
+			if _, ok := getEntry(s, "T01234ABCDE", "deploy-42"); ok {
+				t.Error("expired entry should miss")
+			}
+		})
+	}
+}
+
+func TestIdempotencyStore_SharedAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+	entry := IdempotencyEntry{Fingerprint: "abc", Result: json.RawMessage(`{}`), CreatedAt: time.Now()}
+	if err := NewIdempotencyStore(dir, time.Hour).Put("T01234ABCDE", "k", entry); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// 別プロセス相当の別インスタンスからも参照できる
+	if _, ok := getEntry(NewIdempotencyStore(dir, time.Hour), "T01234ABCDE", "k"); !ok {
+		t.Error("entry should be visible to another store on the same dir")
+	}
+}
+
+func TestIdempotencyStore_Bounded(t *testing.T) {
+	s := NewIdempotencyStore(t.TempDir(), time.Hour)
+	s.maxKeys = 3
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		entry := IdempotencyEntry{Fingerprint: "f", Result: json.RawMessage(`{}`), CreatedAt: base.Add(time.Duration(i) * time.Second)}
+		if err := s.Put("T01234ABCDE", fmt.Sprintf("k%d", i), entry); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+
+	// 上限を超えた分は古いものから削除される
+	for i, want := range []bool{false, false, true, true, true} {
+		if _, ok := getEntry(s, "T01234ABCDE", fmt.Sprintf("k%d", i)); ok != want {
+			t.Errorf("k%d present = %v, want %v", i, ok, want)
+		}
+	}
+}
+
+func TestOpenIdempotency(t *testing.T) {
+	t.Setenv(EnvCacheDir, t.TempDir())
+	if s := OpenIdempotency(0); s != nil {
+		t.Error("OpenIdempotency(0) should return nil (disabled)")
+	}
+	if s := OpenIdempotency(time.Hour); s == nil || s.dir == "" {
+		t.Errorf("OpenIdempotency(1h) = %+v, want disk-backed store", s)
+	}
+}
+
+func TestIdempotencyStore_Reserve(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		dir  string
+	}{
+		{"disk", t.TempDir()},
+		{"memory", ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewIdempotencyStore(tt.dir, time.Hour)
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			s.now = func() time.Time { return now }
+
+			if _, ok, err := s.Reserve("T01234ABCDE", "deploy-42", "abc"); !ok || err != nil {
+				t.Fatalf("first Reserve = %v, %v; want reserved", ok, err)
+			}
+			// 予約中のキーは他の呼び出しから投稿中として見える
+			if e, ok, _ := s.Reserve("T01234ABCDE", "deploy-42", "abc"); ok || !e.Pending || e.Fingerprint != "abc" {
+				t.Errorf("second Reserve = %+v, %v; want pending entry", e, ok)
+			}
+			// 投稿に失敗した場合は予約を取り消し、同じキーで再度予約できる
+			if err := s.Release("T01234ABCDE", "deploy-42"); err != nil {
+				t.Fatalf("Release: %v", err)
+			}
+			if _, ok, _ := s.Reserve("T01234ABCDE", "deploy-42", "abc"); !ok {
+				t.Error("Reserve after Release should succeed")
+			}
+
+			// 投稿済みの記録は Release で削除されず、Reserve はその記録を返す
+			entry := IdempotencyEntry{Fingerprint: "abc", Result: json.RawMessage(`{"ts":"1.2"}`), CreatedAt: now}
+			if err := s.Put("T01234ABCDE", "deploy-42", entry); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			_ = s.Release("T01234ABCDE", "deploy-42")
+			e, ok, _ := s.Reserve("T01234ABCDE", "deploy-42", "abc")
+			var result map[string]string
+			if ok || e.Pending || json.Unmarshal(e.Result, &result) != nil || result["ts"] != "1.2" {
+				t.Errorf("Reserve after Put = %+v, %v; want stored result", e, ok)
+			}
+
+			// 異常終了したプロセスの予約は PendingIdempotencyAge を過ぎると取り直せる
+			if _, ok, _ := s.Reserve("T01234ABCDE", "stale", "abc"); !ok {
+				t.Fatal("Reserve(stale) should succeed")
+			}
+			now = now.Add(PendingIdempotencyAge + time.Second)
+			if _, ok, _ := s.Reserve("T01234ABCDE", "stale", "abc"); !ok {
+				t.Error("stale pending entry should be reservable again")
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/cache"
+	mcpserver "github.com/kai-kou/slack-fast-mcp/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+var flagCacheTeam string
+
+// newCacheCmd は cache サブコマンド（show / clear / warm）を作成する。
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect or clear the on-disk channel and user cache",
+		Long: `Inspect or clear the on-disk cache of channel IDs and user names.
+
+Channel and user lookups are cached per workspace under the user cache directory
+(override with SLACK_FAST_MCP_CACHE_DIR) so that each CLI invocation does not have
+to scan conversations.list again. Entries expire after cache_ttl (default 24h).`,
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show cached workspaces and entry counts",
+		Args:  cobra.NoArgs,
+		RunE:  runCacheShow,
+	}
+
+	clearCmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Delete the cache for all workspaces (or one with --team)",
+		Example: `  # Clear everything
+  slack-fast-mcp cache clear
+
+  # Clear a single workspace
+  slack-fast-mcp cache clear --team T01234ABCDE`,
+		Args: cobra.NoArgs,
+		RunE: runCacheClear,
+	}
+	clearCmd.Flags().StringVar(&flagCacheTeam, "team", "", "only clear the cache for this team ID")
+
+	warmCmd := &cobra.Command{
+		Use:   "warm",
+		Short: "Scan all channels once and store the name-to-ID index",
+		Long: `Scan conversations.list once and store every channel name and ID in the cache,
+so that later commands resolve channel names without listing channels again.
+Useful for large workspaces; the scan stops at channel_scan_max_pages if configured.`,
+		Args: cobra.NoArgs,
+		RunE: runCacheWarm,
+	}
+
+	cmd.AddCommand(showCmd, clearCmd, warmCmd)
+	return cmd
+}
+
+// openCacheStore は設定の cache_ttl でキャッシュストアを開く。
+// キャッシュの参照・削除にはトークンが不要なため、設定のバリデーションは行わない。
+func openCacheStore() (*cache.Store, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine cache directory: %w", err)
+	}
+	return cache.New(dir, mcpserver.DiskCacheTTL(cfg)), nil
+}
+
+// runCacheShow はキャッシュの内容を表示する。
+func runCacheShow(cmd *cobra.Command, args []string) error {
+	store, err := openCacheStore()
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := store.Workspaces()
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		if workspaces == nil {
+			workspaces = []*cache.Workspace{}
+		}
+		out := map[string]interface{}{
+			"ok":         true,
+			"dir":        store.Dir(),
+			"workspaces": workspaces,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "📦 %s\n", store.Dir())
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+	if len(workspaces) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "  (empty)")
+	}
+	for _, w := range workspaces {
+		expired := 0
+		for _, e := range w.Channels {
+			if store.Expired(e) {
+				expired++
+			}
+		}
+		for _, e := range w.Users {
+			if store.Expired(e) {
+				expired++
+			}
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "  %-12s %5d channels %5d users", w.TeamID, len(w.Channels), len(w.Users))
+		if expired > 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "  (%d expired)", expired)
+		}
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+
+	return nil
+}
+
+// runCacheClear はキャッシュを削除する。
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	store, err := openCacheStore()
+	if err != nil {
+		return err
+	}
+
+	cleared, err := store.Clear(flagCacheTeam)
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":      true,
+			"cleared": cleared,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "🧹 Cleared cache for %d workspace(s)\n", cleared)
+	return nil
+}
+
+// runCacheWarm はチャンネル索引を構築してキャッシュに保存する。
+func runCacheWarm(cmd *cobra.Command, args []string) error {
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	// 大規模ワークスペースではレート制限の待機を含めて時間がかかるため長めのタイムアウトにする
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	count, err := client.WarmChannelIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":       true,
+			"channels": count,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "🔥 Indexed %d channels\n", count)
+	return nil
+}
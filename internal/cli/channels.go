@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagChannelTypes    string
+	flagMemberOnly      bool
+	flagPrefix          string
+	flagIncludeArchived bool
+	flagChannelsLimit   int
+)
+
+// newChannelsCmd は channels サブコマンドを作成する。
+func newChannelsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "channels",
+		Short: "List channels in the workspace",
+		Long:  "List channels in the Slack workspace with their IDs, member counts and bot membership.",
+		Example: `  # List public and private channels
+  slack-fast-mcp channels
+
+  # Channels the bot has joined, starting with "proj-"
+  slack-fast-mcp channels --member --prefix proj-
+
+  # Include DMs and group DMs, as JSON
+  slack-fast-mcp channels --types public,private,mpim,im --json`,
+		RunE: runChannels,
+	}
+
+	cmd.Flags().StringVar(&flagChannelTypes, "types", "", "comma-separated types: public, private, mpim, im (default: public,private)")
+	cmd.Flags().BoolVar(&flagMemberOnly, "member", false, "only channels the bot is a member of")
+	cmd.Flags().StringVar(&flagPrefix, "prefix", "", "only channels whose name starts with this prefix")
+	cmd.Flags().BoolVar(&flagIncludeArchived, "include-archived", false, "include archived channels")
+	cmd.Flags().IntVarP(&flagChannelsLimit, "limit", "l", 100, "number of channels to retrieve (1-1000)")
+	cmd.Flags().StringVar(&flagCursor, "cursor", "", "pagination cursor from a previous response")
+
+	return cmd
+}
+
+// runChannels はチャンネル一覧を取得する。
+func runChannels(cmd *cobra.Command, args []string) error {
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := client.ListChannels(ctx, slackclient.ListChannelsOptions{
+		Types:           slackclient.ParseConversationTypes(flagChannelTypes),
+		MemberOnly:      flagMemberOnly,
+		Prefix:          flagPrefix,
+		IncludeArchived: flagIncludeArchived,
+		Limit:           flagChannelsLimit,
+		Cursor:          flagCursor,
+	})
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":          true,
+			"channels":    result.Channels,
+			"has_more":    result.HasMore,
+			"next_cursor": result.NextCursor,
+			"count":       result.Count,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テーブル形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "📚 %d channels\n", result.Count)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+	fmt.Fprintf(cmd.OutOrStdout(), "  %-24s %-12s %7s  %s\n", "NAME", "ID", "MEMBERS", "FLAGS")
+
+	for _, ch := range result.Channels {
+		var flags []string
+		if ch.IsMember {
+			flags = append(flags, "member")
+		}
+		if ch.IsPrivate {
+			flags = append(flags, "private")
+		}
+		if ch.IsArchived {
+			flags = append(flags, "archived")
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "  #%-23s %-12s %7d  %s\n", ch.Name, ch.ID, ch.NumMembers, strings.Join(flags, ","))
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+	if result.HasMore {
+		fmt.Fprintf(cmd.OutOrStdout(), "  (more channels available — use --cursor %s to load more)\n", result.NextCursor)
+	}
+
+	return nil
+}
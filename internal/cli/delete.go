@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newDeleteCmd は delete サブコマンドを作成する。
+func newDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a message posted by the bot",
+		Long:  "Delete a message previously posted by the bot. This cannot be undone.",
+		Example: `  # Delete a message in the default channel
+  slack-fast-mcp delete --timestamp 1234567890.123456
+
+  # Delete a message in a specific channel
+  slack-fast-mcp delete --channel general --timestamp 1234567890.123456`,
+		RunE: runDelete,
+	}
+
+	cmd.Flags().StringVarP(&flagTimestamp, "timestamp", "t", "", "timestamp of the message to delete (required)")
+	_ = cmd.MarkFlagRequired("timestamp")
+
+	return cmd
+}
+
+// runDelete は投稿済みメッセージを削除する。
+func runDelete(cmd *cobra.Command, args []string) error {
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := resolveChannel(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	result, err := client.DeleteMessage(ctx, channel, flagTimestamp)
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":           true,
+			"channel":      result.Channel,
+			"channel_name": result.ChannelName,
+			"ts":           result.TS,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テキスト形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "🗑️  Message %s deleted from #%s\n", result.TS, result.ChannelName)
+
+	return nil
+}
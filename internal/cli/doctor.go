@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	mcpserver "github.com/kai-kou/slack-fast-mcp/internal/mcp"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+// doctor の診断結果
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+	checkSkip = "skip"
+)
+
+// doctorCheck は doctor の診断項目1件分の結果。
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// doctorReport は doctor の診断結果全体。
+type doctorReport struct {
+	OK      bool            `json:"ok"`
+	Profile string          `json:"profile,omitempty"`
+	Sources []config.Source `json:"sources"`
+	Checks  []doctorCheck   `json:"checks"`
+}
+
+// add は診断項目を追加する。
+func (r *doctorReport) add(name, status, detail string) {
+	r.Checks = append(r.Checks, doctorCheck{Name: name, Status: status, Detail: detail})
+}
+
+// count は status の診断項目の数を返す。
+func (r *doctorReport) count(status string) int {
+	n := 0
+	for _, c := range r.Checks {
+		if c.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// newDoctorCmd は doctor サブコマンドを作成する。
+func newDoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose config, token, scopes and channel membership",
+		Long: `Run end-to-end diagnostics and print a pass/fail report:
+
+  - which config files and environment variables were read, and which settings each supplied
+  - whether the config is valid
+  - whether the token works (auth.test)
+  - whether the token has the OAuth scopes each MCP tool needs
+  - whether the bot is a member of the default channel (or --channel)
+
+Exits with a non-zero status if any check fails.`,
+		Example: `  slack-fast-mcp doctor
+  slack-fast-mcp doctor --profile customer --json`,
+		Args: cobra.NoArgs,
+		RunE: runDoctor,
+	}
+}
+
+// runDoctor は設定と Slack 接続の診断を実行する。
+// 設定のバリデーションに失敗しても、トークンがあれば Slack 側の診断を続ける。
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	cfg, err = cfg.Profile(cfg.DefaultProfile)
+	if err != nil {
+		return err
+	}
+
+	report := &doctorReport{Profile: cfg.ProfileName, Sources: cfg.Sources}
+	if report.Sources == nil {
+		report.Sources = []config.Source{}
+	}
+	for _, s := range cfg.Sources {
+		if s.Error != "" {
+			report.add("config "+s.Kind, checkWarn, fmt.Sprintf("%s was ignored: %s", s.Name, s.Error))
+		}
+	}
+	if err := mcpserver.ValidateConfig(cfg); err != nil {
+		report.add("config", checkFail, errorDetail(err))
+	} else {
+		report.add("config", checkPass, "valid")
+	}
+
+	if cfg.Token == "" {
+		report.add("auth", checkSkip, "no token configured")
+	} else {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		defer cancel()
+		diagnoseSlack(ctx, report, cfg, newSlackClient(cfg))
+	}
+
+	report.OK = report.count(checkFail) == 0
+	if flagJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printDoctorReport(cmd, report)
+	}
+
+	if !report.OK {
+		return fmt.Errorf("doctor found %d failing check(s)", report.count(checkFail))
+	}
+	return nil
+}
+
+// diagnoseSlack はトークン・スコープ・デフォルトチャンネルへの参加を診断する。
+func diagnoseSlack(ctx context.Context, report *doctorReport, cfg *config.Config, client slackclient.SlackClient) {
+	info, err := client.AuthTest(ctx)
+	if err != nil {
+		report.add("auth", checkFail, errorDetail(err))
+		return
+	}
+	report.add("auth", checkPass, fmt.Sprintf("team %s (%s) as @%s (%s)", info.Team, info.TeamID, info.User, info.UserID))
+
+	if info.Scopes == nil {
+		report.add("scopes", checkWarn, "the token's scopes could not be determined (no X-OAuth-Scopes header)")
+	} else {
+		for _, ts := range mcpserver.RequiredScopes() {
+			status, detail := scopeStatus(ts, cfg, info)
+			report.add(ts.Tool, status, detail)
+		}
+	}
+
+	channel, err := cfg.ResolveChannel(flagChannel)
+	switch {
+	case err != nil:
+		report.add("channel", checkSkip, "no default channel configured")
+	case slackclient.IsDMTarget(channel):
+		report.add("channel", checkSkip, fmt.Sprintf("%s is a DM target", channel))
+	default:
+		m, err := client.ChannelMembership(ctx, channel)
+		if err != nil {
+			report.add("channel", checkFail, errorDetail(err))
+		} else if !m.IsMember {
+			report.add("channel", checkFail, fmt.Sprintf("bot is not a member of #%s (%s); invite it with /invite @%s", m.ChannelName, m.Channel, info.User))
+		} else {
+			report.add("channel", checkPass, fmt.Sprintf("bot is a member of #%s (%s)", m.ChannelName, m.Channel))
+		}
+	}
+}
+
+// scopeStatus はツール1つ分の必要スコープが付与されているかを判定し、診断結果と詳細を返す。
+func scopeStatus(ts mcpserver.ToolScopes, cfg *config.Config, info *slackclient.AuthInfo) (string, string) {
+	if missing := mcpserver.MissingScopes(ts.Bot, info.Scopes); len(missing) > 0 {
+		return checkFail, "missing bot scope(s): " + strings.Join(missing, ", ")
+	}
+	if len(ts.User) > 0 {
+		switch {
+		case cfg.UserToken == "":
+			return checkSkip, "needs user_token with " + strings.Join(ts.User, ", ")
+		case info.UserScopes == nil:
+			return checkWarn, "the user token's scopes could not be determined"
+		}
+		if missing := mcpserver.MissingScopes(ts.User, info.UserScopes); len(missing) > 0 {
+			return checkFail, "missing user scope(s): " + strings.Join(missing, ", ")
+		}
+	}
+	required := append(append([]string{}, ts.Bot...), ts.User...)
+	if len(required) == 0 {
+		return checkPass, "no scopes required"
+	}
+	return checkPass, strings.Join(required, ", ")
+}
+
+// errorDetail はエラーを診断結果の詳細として表示する文字列に変換する（AppError の場合はヒントも含める）。
+func errorDetail(err error) string {
+	var appErr *apperr.AppError
+	if !errors.As(err, &appErr) {
+		return err.Error()
+	}
+	detail := fmt.Sprintf("[%s] %s", appErr.Code, appErr.Message)
+	if appErr.Err != nil {
+		detail += ": " + appErr.Err.Error()
+	}
+	if appErr.Hint != "" {
+		detail += " — " + appErr.Hint
+	}
+	return detail
+}
+
+// printDoctorReport は診断結果をテキスト形式で出力する。
+func printDoctorReport(cmd *cobra.Command, report *doctorReport) {
+	out := cmd.OutOrStdout()
+	icons := map[string]string{checkPass: "✅", checkWarn: "⚠️ ", checkFail: "❌", checkSkip: "⏭️ "}
+
+	fmt.Fprintln(out, "🩺 slack-fast-mcp doctor")
+	if report.Profile != "" {
+		fmt.Fprintf(out, "   profile: %s\n", report.Profile)
+	}
+	fmt.Fprintf(out, "%s\n", strings.Repeat("─", 60))
+
+	fmt.Fprintln(out, "Config sources (later entries override earlier ones):")
+	if len(report.Sources) == 0 {
+		fmt.Fprintln(out, "  (none)")
+	}
+	for _, s := range report.Sources {
+		values := make([]string, 0, len(s.Fields))
+		for _, f := range s.Fields {
+			values = append(values, f+"="+s.Values[f])
+		}
+		fields := strings.Join(values, ", ")
+		if s.Error != "" {
+			fields = "(ignored: parse error)"
+		} else if fields == "" {
+			fields = "(no settings)"
+		}
+		fmt.Fprintf(out, "  %-7s %s: %s\n", s.Kind, s.Name, fields)
+	}
+	fmt.Fprintf(out, "%s\n", strings.Repeat("─", 60))
+
+	for _, c := range report.Checks {
+		fmt.Fprintf(out, "%s %-32s %s\n", icons[c.Status], c.Name, c.Detail)
+	}
+	fmt.Fprintf(out, "%s\n", strings.Repeat("─", 60))
+	fmt.Fprintf(out, "%d passed, %d failed, %d warning(s), %d skipped\n",
+		report.count(checkPass), report.count(checkFail), report.count(checkWarn), report.count(checkSkip))
+}
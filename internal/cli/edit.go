@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newEditCmd は edit サブコマンドを作成する。
+func newEditCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edit a message posted by the bot",
+		Long:  "Replace the text of a message previously posted by the bot. The display-name hashtag is appended as with post.",
+		Example: `  # Correct a status line
+  slack-fast-mcp edit --timestamp 1234567890.123456 --message "Deploy finished ✅"
+
+  # Edit a message in a specific channel
+  slack-fast-mcp edit --channel general --timestamp 1234567890.123456 --message "Updated"
+
+  # Edit with JSON output
+  slack-fast-mcp edit --timestamp 1234567890.123456 --message "Updated" --json`,
+		RunE: runEdit,
+	}
+
+	cmd.Flags().StringVarP(&flagTimestamp, "timestamp", "t", "", "timestamp of the message to edit (required)")
+	cmd.Flags().StringVarP(&flagMessage, "message", "m", "", "new message text (required)")
+	_ = cmd.MarkFlagRequired("timestamp")
+	_ = cmd.MarkFlagRequired("message")
+
+	return cmd
+}
+
+// runEdit は投稿済みメッセージを更新する。
+func runEdit(cmd *cobra.Command, args []string) error {
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := resolveChannel(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	message := resolveAndTagMessage(cfg, flagMessage)
+	result, err := client.UpdateMessage(ctx, channel, flagTimestamp, message)
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":           true,
+			"channel":      result.Channel,
+			"channel_name": result.ChannelName,
+			"ts":           result.TS,
+			"message":      result.Message,
+			"permalink":    result.Permalink,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テキスト形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ Message updated in #%s\n", result.ChannelName)
+	if result.Permalink != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "🔗 %s\n", result.Permalink)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ var (
 	flagLimit  int
 	flagOldest string
 	flagLatest string
+	flagAll    bool
 )
 
 // newHistoryCmd は history サブコマンドを作成する。
@@ -30,13 +32,21 @@ func newHistoryCmd() *cobra.Command {
   slack-fast-mcp history --channel general --limit 20
 
   # Get messages in JSON format
-  slack-fast-mcp history --channel general --json`,
+  slack-fast-mcp history --channel general --json
+
+  # Get the next (older) page
+  slack-fast-mcp history --channel general --cursor <next_cursor>
+
+  # Stream every message in a time window (JSON: one message per line)
+  slack-fast-mcp history --channel general --all --oldest 1700000000 --latest 1700086400 --json`,
 		RunE: runHistory,
 	}
 
 	cmd.Flags().IntVarP(&flagLimit, "limit", "l", 10, "number of messages to retrieve (1-100)")
 	cmd.Flags().StringVar(&flagOldest, "oldest", "", "start time (Unix timestamp)")
 	cmd.Flags().StringVar(&flagLatest, "latest", "", "end time (Unix timestamp)")
+	cmd.Flags().StringVar(&flagCursor, "cursor", "", "pagination cursor from a previous response")
+	cmd.Flags().BoolVar(&flagAll, "all", false, "fetch every page (within --oldest/--latest) and stream the results")
 
 	return cmd
 }
@@ -53,6 +63,10 @@ func runHistory(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if flagAll {
+		return runHistoryAll(cmd, client, channel)
+	}
+
 	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
 	defer cancel()
 
@@ -60,6 +74,7 @@ func runHistory(cmd *cobra.Command, args []string) error {
 		Limit:  flagLimit,
 		Oldest: flagOldest,
 		Latest: flagLatest,
+		Cursor: flagCursor,
 	})
 	if err != nil {
 		return err
@@ -72,6 +87,7 @@ func runHistory(cmd *cobra.Command, args []string) error {
 			"channel_name": result.ChannelName,
 			"messages":     result.Messages,
 			"has_more":     result.HasMore,
+			"next_cursor":  result.NextCursor,
 			"count":        result.Count,
 		}
 		encoder := json.NewEncoder(cmd.OutOrStdout())
@@ -84,7 +100,82 @@ func runHistory(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(cmd.OutOrStdout(), "📋 #%s — %d messages\n", result.ChannelName, result.Count)
 	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
 
-	for i, msg := range result.Messages {
+	printMessages(cmd.OutOrStdout(), result.Messages)
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+	if result.HasMore {
+		fmt.Fprintf(cmd.OutOrStdout(), "  (more messages available — use --cursor %s or --all to load more)\n", result.NextCursor)
+	}
+
+	return nil
+}
+
+// runHistoryAll は全ページを順に取得し、取得したページから順次出力する。
+// JSON 出力時は1行1メッセージ（JSON Lines）で出力する。
+func runHistoryAll(cmd *cobra.Command, client slackclient.SlackClient, channel string) error {
+	out := cmd.OutOrStdout()
+
+	// --limit 未指定時はページあたりの最大件数で取得する
+	pageSize := flagLimit
+	if !cmd.Flags().Changed("limit") {
+		pageSize = 100
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+
+	cursor := flagCursor
+	total := 0
+	headerPrinted := false
+	for {
+		// ページ単位でタイムアウトを設定（全体はシグナルでのみ中断）
+		ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+		result, err := client.GetHistory(ctx, channel, slackclient.HistoryOptions{
+			Limit:  pageSize,
+			Oldest: flagOldest,
+			Latest: flagLatest,
+			Cursor: cursor,
+		})
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if flagJSON {
+			for _, msg := range result.Messages {
+				if err := encoder.Encode(msg); err != nil {
+					return err
+				}
+			}
+		} else {
+			// 先頭のページが空でもヘッダーは1回だけ出力する
+			if !headerPrinted {
+				fmt.Fprintf(out, "📋 #%s\n", result.ChannelName)
+				fmt.Fprintf(out, "%s\n", strings.Repeat("─", 60))
+				headerPrinted = true
+			} else if total > 0 && len(result.Messages) > 0 {
+				fmt.Fprintf(out, "  %s\n", strings.Repeat("·", 56))
+			}
+			printMessages(out, result.Messages)
+		}
+		total += result.Count
+
+		if !result.HasMore || result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	if !flagJSON {
+		fmt.Fprintf(out, "%s\n", strings.Repeat("─", 60))
+		fmt.Fprintf(out, "  %d messages\n", total)
+	}
+	return nil
+}
+
+// printMessages はメッセージ一覧をテーブル形式で出力する。
+func printMessages(w io.Writer, messages []slackclient.HistoryMessage) {
+	for i, msg := range messages {
 		userName := msg.UserName
 		if userName == "" {
 			userName = msg.User
@@ -96,22 +187,15 @@ func runHistory(cmd *cobra.Command, args []string) error {
 			text = text[:200] + "..."
 		}
 
-		fmt.Fprintf(cmd.OutOrStdout(), "  @%-16s %s\n", userName, text)
+		fmt.Fprintf(w, "  @%-16s %s\n", userName, text)
 
 		// スレッド情報
 		if msg.ReplyCount > 0 {
-			fmt.Fprintf(cmd.OutOrStdout(), "  %s💬 %d replies (thread_ts: %s)\n", strings.Repeat(" ", 18), msg.ReplyCount, msg.TS)
+			fmt.Fprintf(w, "  %s💬 %d replies (thread_ts: %s)\n", strings.Repeat(" ", 18), msg.ReplyCount, msg.TS)
 		}
 
-		if i < len(result.Messages)-1 {
-			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", strings.Repeat("·", 56))
+		if i < len(messages)-1 {
+			fmt.Fprintf(w, "  %s\n", strings.Repeat("·", 56))
 		}
 	}
-
-	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
-	if result.HasMore {
-		fmt.Fprintf(cmd.OutOrStdout(), "  (more messages available — use --limit to load more)\n")
-	}
-
-	return nil
 }
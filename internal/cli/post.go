@@ -4,12 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
 	"github.com/spf13/cobra"
 )
 
-var flagMessage string
+var (
+	flagMessage        string
+	flagBlocks         string
+	flagIdempotencyKey string
+)
 
 // newPostCmd は post サブコマンドを作成する。
 func newPostCmd() *cobra.Command {
@@ -24,11 +31,19 @@ func newPostCmd() *cobra.Command {
   slack-fast-mcp post --channel general --message "Hello World"
 
   # Post with JSON output
-  slack-fast-mcp post --channel general --message "Hello" --json`,
+  slack-fast-mcp post --channel general --message "Hello" --json
+
+  # Post Block Kit blocks (message is the notification fallback text)
+  slack-fast-mcp post --message "Build passed" --blocks @summary.json
+
+  # Safe to re-run: a second run with the same key returns the first post
+  slack-fast-mcp post --message "Deploy v1.2.3 done" --idempotency-key deploy-v1.2.3`,
 		RunE: runPost,
 	}
 
 	cmd.Flags().StringVarP(&flagMessage, "message", "m", "", "message text to post (required)")
+	cmd.Flags().StringVar(&flagBlocks, "blocks", "", "Block Kit blocks as a JSON array, or @file to read from a file")
+	cmd.Flags().StringVar(&flagIdempotencyKey, "idempotency-key", "", "unique key; re-running with the same key returns the first post instead of posting again")
 	_ = cmd.MarkFlagRequired("message")
 
 	return cmd
@@ -46,11 +61,19 @@ func runPost(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	blocks, err := readBlocksFlag()
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
 	defer cancel()
 
 	message := resolveAndTagMessage(cfg, flagMessage)
-	result, err := client.PostMessage(ctx, channel, message)
+	result, err := client.PostMessage(ctx, channel, message, slackclient.PostOptions{
+		Blocks:         blocks,
+		IdempotencyKey: flagIdempotencyKey,
+	})
 	if err != nil {
 		return err
 	}
@@ -64,6 +87,9 @@ func runPost(cmd *cobra.Command, args []string) error {
 			"message":      result.Message,
 			"permalink":    result.Permalink,
 		}
+		if result.Replayed {
+			out["replayed"] = true
+		}
 		encoder := json.NewEncoder(cmd.OutOrStdout())
 		encoder.SetIndent("", "  ")
 		encoder.SetEscapeHTML(false)
@@ -71,10 +97,34 @@ func runPost(cmd *cobra.Command, args []string) error {
 	}
 
 	// テキスト形式出力
-	fmt.Fprintf(cmd.OutOrStdout(), "✅ Message posted to #%s\n", result.ChannelName)
+	if result.Replayed {
+		fmt.Fprintf(cmd.OutOrStdout(), "♻️  Already posted to #%s with this idempotency key (not posted again)\n", result.ChannelName)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "✅ Message posted to #%s\n", result.ChannelName)
+	}
 	if result.Permalink != "" {
 		fmt.Fprintf(cmd.OutOrStdout(), "🔗 %s\n", result.Permalink)
 	}
 
 	return nil
 }
+
+// readBlocksFlag は --blocks の値（JSON 配列または @ファイルパス）を読み込み、検証する。
+func readBlocksFlag() (string, error) {
+	raw := flagBlocks
+	if path, ok := strings.CutPrefix(raw, "@"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read blocks file: %w", err)
+		}
+		raw = string(data)
+	}
+	if strings.TrimSpace(raw) == "" {
+		return "", nil
+	}
+
+	if _, err := slackclient.ParseBlocks(raw); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
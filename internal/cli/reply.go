@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
 	"github.com/spf13/cobra"
 )
 
@@ -24,12 +25,17 @@ func newReplyCmd() *cobra.Command {
   slack-fast-mcp reply --channel general --thread-ts 1234567890.123456 --message "Reply"
 
   # Reply with JSON output
-  slack-fast-mcp reply --thread-ts 1234567890.123456 --message "Reply" --json`,
+  slack-fast-mcp reply --thread-ts 1234567890.123456 --message "Reply" --json
+
+  # Reply with Block Kit blocks
+  slack-fast-mcp reply --thread-ts 1234567890.123456 --message "Details" --blocks '[{"type":"divider"}]'`,
 		RunE: runReply,
 	}
 
 	cmd.Flags().StringVarP(&flagThreadTS, "thread-ts", "t", "", "thread timestamp to reply to (required)")
 	cmd.Flags().StringVarP(&flagMessage, "message", "m", "", "reply message text (required)")
+	cmd.Flags().StringVar(&flagBlocks, "blocks", "", "Block Kit blocks as a JSON array, or @file to read from a file")
+	cmd.Flags().StringVar(&flagIdempotencyKey, "idempotency-key", "", "unique key; re-running with the same key returns the first reply instead of posting again")
 	_ = cmd.MarkFlagRequired("thread-ts")
 	_ = cmd.MarkFlagRequired("message")
 
@@ -48,11 +54,19 @@ func runReply(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	blocks, err := readBlocksFlag()
+	if err != nil {
+		return err
+	}
+
 	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
 	defer cancel()
 
 	message := resolveAndTagMessage(cfg, flagMessage)
-	result, err := client.PostThread(ctx, channel, flagThreadTS, message)
+	result, err := client.PostThread(ctx, channel, flagThreadTS, message, slackclient.PostOptions{
+		Blocks:         blocks,
+		IdempotencyKey: flagIdempotencyKey,
+	})
 	if err != nil {
 		return err
 	}
@@ -67,6 +81,9 @@ func runReply(cmd *cobra.Command, args []string) error {
 			"message":      result.Message,
 			"permalink":    result.Permalink,
 		}
+		if result.Replayed {
+			out["replayed"] = true
+		}
 		encoder := json.NewEncoder(cmd.OutOrStdout())
 		encoder.SetIndent("", "  ")
 		encoder.SetEscapeHTML(false)
@@ -74,7 +91,11 @@ func runReply(cmd *cobra.Command, args []string) error {
 	}
 
 	// テキスト形式出力
-	fmt.Fprintf(cmd.OutOrStdout(), "✅ Reply posted to thread in #%s\n", result.ChannelName)
+	if result.Replayed {
+		fmt.Fprintf(cmd.OutOrStdout(), "♻️  Already replied in #%s with this idempotency key (not posted again)\n", result.ChannelName)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "✅ Reply posted to thread in #%s\n", result.ChannelName)
+	}
 	if result.Permalink != "" {
 		fmt.Fprintf(cmd.OutOrStdout(), "🔗 %s\n", result.Permalink)
 	}
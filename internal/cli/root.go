@@ -1,5 +1,5 @@
 // Package cli implements the CLI layer using cobra for slack-fast-mcp.
-// Provides subcommands: serve, post, history, reply, react, unreact, setup, version.
+// Provides subcommands: serve, post, history, thread, channels, search, reply, edit, delete, upload, schedule, react, unreact, cache, doctor, setup, version.
 package cli
 
 import (
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	mcpserver "github.com/kai-kou/slack-fast-mcp/internal/mcp"
 	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +23,7 @@ var (
 // グローバルフラグ
 var (
 	flagConfig      string
+	flagProfile     string
 	flagToken       string
 	flagChannel     string
 	flagDisplayName string
@@ -44,14 +46,30 @@ Without any subcommand, starts the MCP Server in stdio mode.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runServe(cmd, args)
 		},
+		// --verbose の場合はコマンド実行中の Slack API リトライ回数を集計し、終了時に stderr へ表示する
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if !flagVerbose {
+				return
+			}
+			ctx, stats := slackclient.WithRetryStats(cmd.Context())
+			cmd.SetContext(ctx)
+			// PersistentPostRun は RunE がエラーを返すと呼ばれないため、RunE を包んで失敗時にも表示する
+			if run := cmd.RunE; run != nil {
+				cmd.RunE = func(cmd *cobra.Command, args []string) error {
+					defer printRetryStats(cmd, stats)
+					return run(cmd, args)
+				}
+			}
+		},
 		SilenceUsage:  true,
 		SilenceErrors: true,
 	}
 
 	// グローバルフラグ
 	rootCmd.PersistentFlags().StringVar(&flagConfig, "config", "", "config file path (default: .slack-mcp.json)")
+	rootCmd.PersistentFlags().StringVar(&flagProfile, "profile", "", "workspace profile from config (default: default_profile, or the top-level settings)")
 	rootCmd.PersistentFlags().StringVar(&flagToken, "token", "", "Slack Bot Token (overrides config/env)")
-	rootCmd.PersistentFlags().StringVar(&flagChannel, "channel", "", "channel name or ID")
+	rootCmd.PersistentFlags().StringVar(&flagChannel, "channel", "", "channel name or ID, or @user / email / user ID for a DM")
 	rootCmd.PersistentFlags().StringVar(&flagDisplayName, "display-name", "", "sender display name (appends #name hashtag)")
 	rootCmd.PersistentFlags().BoolVar(&flagVerbose, "verbose", false, "enable verbose output")
 	rootCmd.PersistentFlags().BoolVar(&flagJSON, "json", false, "output in JSON format")
@@ -60,15 +78,31 @@ Without any subcommand, starts the MCP Server in stdio mode.`,
 	rootCmd.AddCommand(newServeCmd())
 	rootCmd.AddCommand(newPostCmd())
 	rootCmd.AddCommand(newHistoryCmd())
+	rootCmd.AddCommand(newThreadCmd())
+	rootCmd.AddCommand(newChannelsCmd())
+	rootCmd.AddCommand(newSearchCmd())
 	rootCmd.AddCommand(newReplyCmd())
+	rootCmd.AddCommand(newEditCmd())
+	rootCmd.AddCommand(newDeleteCmd())
+	rootCmd.AddCommand(newUploadCmd())
+	rootCmd.AddCommand(newScheduleCmd())
 	rootCmd.AddCommand(newReactCmd())
 	rootCmd.AddCommand(newUnreactCmd())
+	rootCmd.AddCommand(newCacheCmd())
+	rootCmd.AddCommand(newDoctorCmd())
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newSetupCmd())
 
 	return rootCmd
 }
 
+// printRetryStats は stats にリトライがあれば回数を stderr に表示する。
+func printRetryStats(cmd *cobra.Command, stats *slackclient.RetryStats) {
+	if stats.Total() > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "🔁 Retried %d time(s): %s\n", stats.Total(), stats.String())
+	}
+}
+
 // loadConfig はグローバルフラグを考慮して設定を読み込む。
 func loadConfig() (*config.Config, error) {
 	var cfg *config.Config
@@ -88,9 +122,23 @@ func loadConfig() (*config.Config, error) {
 		return nil, err
 	}
 
-	// --token フラグで上書き
+	// --profile でプロファイル未指定時のワークスペースを切り替える
+	if flagProfile != "" {
+		if _, err := cfg.Profile(flagProfile); err != nil {
+			return nil, err
+		}
+		cfg.DefaultProfile = flagProfile
+	}
+
+	// --token フラグで上書き（選択中のプロファイルがあればそのトークンを上書きする）
 	if flagToken != "" {
-		cfg.Token = flagToken
+		if p := cfg.Profiles[cfg.DefaultProfile]; p != nil {
+			p.Token = flagToken
+		} else {
+			cfg.Token = flagToken
+		}
+		cfg.Sources = append(cfg.Sources, config.Source{Kind: config.SourceFlag, Name: "--token", Fields: []string{"token"},
+			Values: map[string]string{"token": config.MaskSetting("token", flagToken)}})
 	}
 
 	// --verbose でログレベル上書き
@@ -105,25 +153,30 @@ func loadConfig() (*config.Config, error) {
 var clientFactory func(token string) slackclient.SlackClient
 
 // newSlackClient はSlackClientを生成する。clientFactoryが設定されていればそれを使用する。
-func newSlackClient(token string) slackclient.SlackClient {
+func newSlackClient(cfg *config.Config) slackclient.SlackClient {
 	if clientFactory != nil {
-		return clientFactory(token)
+		return clientFactory(cfg.Token)
 	}
-	return slackclient.NewClient(token)
+	return slackclient.NewClient(cfg.Token, mcpserver.ClientOptions(cfg, mcpserver.NewLogger(cfg, os.Stderr))...)
 }
 
 // loadConfigAndClient は設定読み込み + バリデーション + Slackクライアント作成をまとめて行う。
+// プロファイルがある場合は --profile（未指定時は default_profile）のワークスペースを使用する。
 func loadConfigAndClient() (*config.Config, slackclient.SlackClient, error) {
 	cfg, err := loadConfig()
 	if err != nil {
 		return nil, nil, err
 	}
+	cfg, err = cfg.Profile(cfg.DefaultProfile)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if err := cfg.Validate(); err != nil {
+	if err := mcpserver.ValidateConfig(cfg); err != nil {
 		return nil, nil, err
 	}
 
-	client := newSlackClient(cfg.Token)
+	client := newSlackClient(cfg)
 	return cfg, client, nil
 }
 
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagPostAt        string
+	flagScheduleLimit int
+)
+
+// newScheduleCmd は schedule サブコマンド（post / list / cancel）を作成する。
+func newScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Schedule, list and cancel messages posted at a later time",
+		Long: `Schedule messages with chat.scheduleMessage, list pending ones and cancel them.
+
+--at accepts a Unix timestamp, RFC3339 (2026-01-01T09:00:00+09:00) or a relative
+duration such as "in 2h", "in 30m" or "in 1d". Slack allows up to 120 days ahead.`,
+	}
+
+	postCmd := &cobra.Command{
+		Use:   "post",
+		Short: "Schedule a message",
+		Example: `  # Remind the default channel in two hours
+  slack-fast-mcp schedule post --at "in 2h" --message "Deploy window opens"
+
+  # Schedule at an absolute time
+  slack-fast-mcp schedule post --channel releases --at 2026-01-01T09:00:00+09:00 --message "Happy new year"`,
+		Args: cobra.NoArgs,
+		RunE: runSchedulePost,
+	}
+	postCmd.Flags().StringVar(&flagPostAt, "at", "", `when to post: Unix time, RFC3339 or "in 2h" (required)`)
+	postCmd.Flags().StringVarP(&flagMessage, "message", "m", "", "message text to post (required)")
+	postCmd.Flags().StringVar(&flagBlocks, "blocks", "", "Block Kit blocks as a JSON array, or @file to read from a file")
+	_ = postCmd.MarkFlagRequired("at")
+	_ = postCmd.MarkFlagRequired("message")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List pending scheduled messages (all channels unless --channel is given)",
+		Args:  cobra.NoArgs,
+		RunE:  runScheduleList,
+	}
+	listCmd.Flags().IntVarP(&flagScheduleLimit, "limit", "l", 100, "number of scheduled messages to retrieve (1-1000)")
+	listCmd.Flags().StringVar(&flagCursor, "cursor", "", "pagination cursor from a previous response")
+
+	cancelCmd := &cobra.Command{
+		Use:   "cancel <scheduled_message_id>",
+		Short: "Cancel a scheduled message before it is posted",
+		Example: `  # Cancel using the ID shown by "schedule list"
+  slack-fast-mcp schedule cancel Q1298393284 --channel releases`,
+		Args: cobra.ExactArgs(1),
+		RunE: runScheduleCancel,
+	}
+
+	cmd.AddCommand(postCmd, listCmd, cancelCmd)
+	return cmd
+}
+
+// runSchedulePost はメッセージを予約投稿する。
+func runSchedulePost(cmd *cobra.Command, args []string) error {
+	postAt, err := slackclient.ParsePostAt(flagPostAt, time.Now())
+	if err != nil {
+		return err
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := resolveChannel(cfg)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := readBlocksFlag()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	message := resolveAndTagMessage(cfg, flagMessage)
+	result, err := client.ScheduleMessage(ctx, channel, message, postAt, slackclient.PostOptions{Blocks: blocks})
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":                   true,
+			"channel":              result.Channel,
+			"channel_name":         result.ChannelName,
+			"scheduled_message_id": result.ScheduledMessageID,
+			"post_at":              result.PostAt,
+			"message":              result.Message,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テキスト形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "⏰ Message scheduled to #%s at %s\n", result.ChannelName, formatPostAt(result.PostAt))
+	fmt.Fprintf(cmd.OutOrStdout(), "🆔 %s\n", result.ScheduledMessageID)
+
+	return nil
+}
+
+// runScheduleList は予約投稿の一覧を表示する。
+func runScheduleList(cmd *cobra.Command, args []string) error {
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	// 一覧は全チャンネルが対象のため、default_channel にはフォールバックしない
+	result, err := client.ListScheduledMessages(ctx, slackclient.ListScheduledOptions{
+		Channel: flagChannel,
+		Limit:   flagScheduleLimit,
+		Cursor:  flagCursor,
+	})
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":                 true,
+			"scheduled_messages": result.Messages,
+			"has_more":           result.HasMore,
+			"next_cursor":        result.NextCursor,
+			"count":              result.Count,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テーブル形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "⏰ %d scheduled messages\n", result.Count)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+
+	for _, m := range result.Messages {
+		channel := m.Channel
+		if m.ChannelName != "" {
+			channel = "#" + m.ChannelName
+		}
+		text := strings.ReplaceAll(m.Text, "\n", " ")
+		if runes := []rune(text); len(runes) > 40 {
+			text = string(runes[:40]) + "…"
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "  %-14s %s  %-14s %s\n", m.ID, formatPostAt(m.PostAt), channel, text)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+	if result.HasMore {
+		fmt.Fprintf(cmd.OutOrStdout(), "  (more scheduled messages available — use --cursor %s to load more)\n", result.NextCursor)
+	}
+
+	return nil
+}
+
+// runScheduleCancel は予約投稿を取り消す。
+func runScheduleCancel(cmd *cobra.Command, args []string) error {
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := resolveChannel(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	result, err := client.DeleteScheduledMessage(ctx, channel, args[0])
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":                   true,
+			"channel":              result.Channel,
+			"channel_name":         result.ChannelName,
+			"scheduled_message_id": result.ScheduledMessageID,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テキスト形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "🗑️  Scheduled message %s cancelled in #%s\n", result.ScheduledMessageID, result.ChannelName)
+
+	return nil
+}
+
+// formatPostAt は予約時刻（Unix 秒）をローカル時刻で表示する。
+func formatPostAt(unix int64) string {
+	return time.Unix(unix, 0).Format("2006-01-02 15:04")
+}
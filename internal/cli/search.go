@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagSearchUser   string
+	flagSearchAfter  string
+	flagSearchBefore string
+	flagSearchOn     string
+	flagSearchSort   string
+	flagSearchDir    string
+	flagSearchCount  int
+	flagSearchPage   int
+)
+
+// newSearchCmd は search サブコマンドを作成する。
+func newSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search <query>",
+		Short: "Search messages across the workspace",
+		Long: `Search messages across the Slack workspace using search.messages.
+
+Requires a user token (xoxp-) with the search:read scope, set via
+user_token in config or the SLACK_USER_TOKEN environment variable.
+Unlike other commands, --channel does not fall back to default_channel.`,
+		Example: `  # Search the whole workspace
+  slack-fast-mcp search "deploy failed"
+
+  # Narrow down by channel, user and date
+  slack-fast-mcp search release --channel general --user alice --after 2024-01-01
+
+  # Newest first, as JSON
+  slack-fast-mcp search incident --sort timestamp --json`,
+		Args: cobra.ArbitraryArgs,
+		RunE: runSearch,
+	}
+
+	cmd.Flags().StringVar(&flagSearchUser, "user", "", "only messages from this user name or ID")
+	cmd.Flags().StringVar(&flagSearchAfter, "after", "", "only messages after this date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flagSearchBefore, "before", "", "only messages before this date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flagSearchOn, "on", "", "only messages on this date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&flagSearchSort, "sort", "score", "sort order: score or timestamp")
+	cmd.Flags().StringVar(&flagSearchDir, "sort-dir", "desc", "sort direction: desc or asc")
+	cmd.Flags().IntVarP(&flagSearchCount, "count", "n", 20, "number of results per page (1-100)")
+	cmd.Flags().IntVar(&flagSearchPage, "page", 1, "page number (1-100)")
+
+	return cmd
+}
+
+// runSearch はメッセージを検索する。
+func runSearch(cmd *cobra.Command, args []string) error {
+	query := strings.TrimSpace(strings.Join(args, " "))
+	if query == "" {
+		return apperr.New(apperr.CodeNoQuery, "検索クエリが空です", nil)
+	}
+	if err := slackclient.ValidateSearchSort(flagSearchSort, flagSearchDir); err != nil {
+		return err
+	}
+
+	_, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	result, err := client.SearchMessages(ctx, slackclient.SearchOptions{
+		Query:   query,
+		Channel: flagChannel,
+		User:    flagSearchUser,
+		After:   flagSearchAfter,
+		Before:  flagSearchBefore,
+		On:      flagSearchOn,
+		Sort:    flagSearchSort,
+		SortDir: flagSearchDir,
+		Count:   flagSearchCount,
+		Page:    flagSearchPage,
+	})
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":       true,
+			"query":    result.Query,
+			"messages": result.Messages,
+			"total":    result.Total,
+			"page":     result.Page,
+			"pages":    result.Pages,
+			"count":    result.Count,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テーブル形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "🔍 %s — %d of %d results\n", result.Query, result.Count, result.Total)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+
+	for i, msg := range result.Messages {
+		fmt.Fprintf(cmd.OutOrStdout(), "  #%s\n", msg.ChannelName)
+		printMessages(cmd.OutOrStdout(), []slackclient.HistoryMessage{msg.HistoryMessage})
+		fmt.Fprintf(cmd.OutOrStdout(), "  %s%s\n", strings.Repeat(" ", 18), msg.Permalink)
+		if i < len(result.Messages)-1 {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", strings.Repeat("·", 56))
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+	if result.Page < result.Pages {
+		fmt.Fprintf(cmd.OutOrStdout(), "  (page %d of %d — use --page %d to see more)\n", result.Page, result.Pages, result.Page+1)
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 
 	mcpserver "github.com/kai-kou/slack-fast-mcp/internal/mcp"
@@ -8,14 +9,37 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	flagTransport string
+	flagListen    string
+)
+
 // newServeCmd は serve サブコマンドを作成する。
 func newServeCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "serve",
-		Short: "Start MCP Server in stdio mode",
-		Long:  "Start the MCP Server using stdio transport. This is the default mode when no subcommand is specified.",
-		RunE:  runServe,
+		Short: "Start MCP Server (stdio, Streamable HTTP or SSE)",
+		Long: `Start the MCP Server. This is the default mode when no subcommand is specified.
+
+Transports:
+  stdio  Communicate over stdin/stdout (default, for a single MCP client)
+  http   Streamable HTTP at http://<listen>/mcp (shared by multiple agents)
+  sse    Server-Sent Events at http://<listen>/sse and /message (legacy clients)`,
+		Example: `  # Start in stdio mode (default)
+  slack-fast-mcp serve
+
+  # Share one instance over Streamable HTTP
+  slack-fast-mcp serve --transport http --listen 127.0.0.1:8080
+
+  # Serve legacy SSE clients
+  slack-fast-mcp serve --transport sse --listen :8080`,
+		RunE: runServe,
 	}
+
+	cmd.Flags().StringVar(&flagTransport, "transport", mcpserver.TransportStdio, "transport: stdio, http or sse")
+	cmd.Flags().StringVar(&flagListen, "listen", "127.0.0.1:8080", "listen address for http/sse transport")
+
+	return cmd
 }
 
 // runServe は MCP Server モードで起動する。
@@ -25,7 +49,7 @@ func runServe(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if err := cfg.Validate(); err != nil {
+	if err := mcpserver.ValidateConfig(cfg); err != nil {
 		return err
 	}
 
@@ -34,6 +58,20 @@ func runServe(cmd *cobra.Command, args []string) error {
 
 	// MCP Server 起動
 	s := mcpserver.NewServer(cfg)
-	stdioServer := server.NewStdioServer(s)
-	return stdioServer.Listen(cmd.Context(), os.Stdin, os.Stdout)
+
+	switch flagTransport {
+	case "", mcpserver.TransportStdio:
+		stdioServer := server.NewStdioServer(s)
+		return stdioServer.Listen(cmd.Context(), os.Stdin, os.Stdout)
+	case mcpserver.TransportHTTP, mcpserver.TransportSSE:
+		// リクエストログは stderr に出力する
+		return mcpserver.ListenHTTP(cmd.Context(), s, mcpserver.HTTPOptions{
+			Transport:  flagTransport,
+			Addr:       flagListen,
+			Logger:     mcpserver.NewLogger(cfg, cmd.ErrOrStderr()),
+			AuthTokens: cfg.AuthTokens,
+		})
+	default:
+		return fmt.Errorf("unsupported transport %q (must be one of: stdio, http, sse)", flagTransport)
+	}
 }
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagThreadLimit int
+	flagCursor      string
+)
+
+// newThreadCmd は thread サブコマンドを作成する。
+func newThreadCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "thread",
+		Short: "Get replies in a message thread",
+		Long:  "Retrieve the parent message and replies of a Slack message thread.",
+		Example: `  # Read a thread in the default channel
+  slack-fast-mcp thread --ts 1234567890.123456
+
+  # Read a thread in a specific channel
+  slack-fast-mcp thread --channel general --ts 1234567890.123456
+
+  # Get the thread in JSON format
+  slack-fast-mcp thread --ts 1234567890.123456 --json`,
+		RunE: runThread,
+	}
+
+	cmd.Flags().StringVarP(&flagThreadTS, "ts", "t", "", "timestamp of the parent message (required)")
+	cmd.Flags().IntVarP(&flagThreadLimit, "limit", "l", 50, "number of messages to retrieve (1-200)")
+	cmd.Flags().StringVar(&flagCursor, "cursor", "", "pagination cursor from a previous response")
+	_ = cmd.MarkFlagRequired("ts")
+
+	return cmd
+}
+
+// runThread はスレッドの返信一覧を取得する。
+func runThread(cmd *cobra.Command, args []string) error {
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := resolveChannel(cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	result, err := client.GetThreadReplies(ctx, channel, flagThreadTS, slackclient.ThreadOptions{
+		Limit:  flagThreadLimit,
+		Cursor: flagCursor,
+	})
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":           true,
+			"channel":      result.Channel,
+			"channel_name": result.ChannelName,
+			"thread_ts":    result.ThreadTS,
+			"messages":     result.Messages,
+			"has_more":     result.HasMore,
+			"next_cursor":  result.NextCursor,
+			"count":        result.Count,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テーブル形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "🧵 #%s — thread %s — %d messages\n", result.ChannelName, result.ThreadTS, result.Count)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+
+	printMessages(cmd.OutOrStdout(), result.Messages)
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", strings.Repeat("─", 60))
+	if result.HasMore {
+		fmt.Fprintf(cmd.OutOrStdout(), "  (more replies available — use --cursor %s to load more)\n", result.NextCursor)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagUploadFile    string
+	flagUploadContent string
+	flagFilename      string
+	flagTitle         string
+	flagSnippetType   string
+	flagComment       string
+)
+
+// newUploadCmd は upload サブコマンドを作成する。
+func newUploadCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upload",
+		Short: "Upload a file or text snippet to a Slack channel",
+		Long: `Upload a file or text snippet to a Slack channel or thread.
+
+Use --file to upload a local file ("-" reads from stdin), or --content for inline text.
+Requires the files:write scope.`,
+		Example: `  # Upload a build log to the default channel
+  slack-fast-mcp upload --file build.log
+
+  # Share a diff in a thread with a comment
+  git diff | slack-fast-mcp upload --file - --filename changes.diff --snippet-type diff \
+    --thread-ts 1234567890.123456 --comment "Proposed fix"
+
+  # Upload inline text
+  slack-fast-mcp upload --channel general --content "hello" --filename hello.txt --json`,
+		RunE: runUpload,
+	}
+
+	cmd.Flags().StringVarP(&flagUploadFile, "file", "f", "", `local file to upload ("-" for stdin)`)
+	cmd.Flags().StringVar(&flagUploadContent, "content", "", "inline text content to upload")
+	cmd.Flags().StringVar(&flagFilename, "filename", "", "file name shown in Slack (default: base name of --file, or snippet.txt)")
+	cmd.Flags().StringVar(&flagTitle, "title", "", "file title (default: file name)")
+	cmd.Flags().StringVar(&flagSnippetType, "snippet-type", "", "syntax type for text snippets (e.g. text, diff, go)")
+	cmd.Flags().StringVar(&flagThreadTS, "thread-ts", "", "share the file in this thread")
+	cmd.Flags().StringVar(&flagComment, "comment", "", "message posted together with the file")
+	cmd.MarkFlagsMutuallyExclusive("file", "content")
+	cmd.MarkFlagsOneRequired("file", "content")
+
+	return cmd
+}
+
+// runUpload はファイルをアップロードする。
+func runUpload(cmd *cobra.Command, args []string) error {
+	data := []byte(flagUploadContent)
+	filename := flagFilename
+	switch flagUploadFile {
+	case "":
+	case "-":
+		b, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return apperr.New(apperr.CodeFileReadError, "標準入力を読み込めません", err)
+		}
+		data = b
+	default:
+		b, err := os.ReadFile(flagUploadFile)
+		if err != nil {
+			return apperr.New(apperr.CodeFileReadError,
+				fmt.Sprintf("ファイルを読み込めません: %s", flagUploadFile), err)
+		}
+		data = b
+		if filename == "" {
+			filename = filepath.Base(flagUploadFile)
+		}
+	}
+	if filename == "" {
+		filename = "snippet.txt"
+	}
+
+	cfg, client, err := loadConfigAndClient()
+	if err != nil {
+		return err
+	}
+
+	channel, err := resolveChannel(cfg)
+	if err != nil {
+		return err
+	}
+
+	// アップロードはサイズに応じて時間がかかるため長めのタイムアウトにする
+	ctx, cancel := context.WithTimeout(cmd.Context(), 60*time.Second)
+	defer cancel()
+
+	result, err := client.UploadFile(ctx, channel, slackclient.UploadOptions{
+		Filename:       filename,
+		Title:          flagTitle,
+		Content:        data,
+		ThreadTS:       flagThreadTS,
+		InitialComment: flagComment,
+		SnippetType:    flagSnippetType,
+	})
+	if err != nil {
+		return err
+	}
+
+	if flagJSON {
+		out := map[string]interface{}{
+			"ok":           true,
+			"channel":      result.Channel,
+			"channel_name": result.ChannelName,
+			"file_id":      result.FileID,
+			"filename":     result.Filename,
+			"title":        result.Title,
+			"size":         result.Size,
+			"thread_ts":    result.ThreadTS,
+			"permalink":    result.Permalink,
+		}
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(out)
+	}
+
+	// テキスト形式出力
+	fmt.Fprintf(cmd.OutOrStdout(), "✅ Uploaded %s (%d bytes) to #%s\n", result.Filename, result.Size, result.ChannelName)
+	if result.Permalink != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "🔗 %s\n", result.Permalink)
+	}
+
+	return nil
+}
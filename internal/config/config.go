@@ -7,7 +7,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
 )
@@ -18,6 +20,138 @@ type Config struct {
 	DefaultChannel string `json:"default_channel"`
 	DisplayName    string `json:"display_name"`
 	LogLevel       string `json:"log_level"`
+
+	// LogFormat は stderr に出力するログの形式（"text" または "json"）。空の場合は text。
+	LogFormat string `json:"log_format,omitempty"`
+
+	// UserToken はメッセージ検索用のユーザートークン（xoxp-）。
+	// search.messages は Bot トークンでは呼び出せないため、検索を使う場合のみ設定する。
+	UserToken string `json:"user_token,omitempty"`
+
+	// UploadDirs は slack_upload_file でローカルファイルのアップロードを許可するディレクトリ。
+	// 空の場合はローカルファイルを指定できない（content による直接指定のみ）。
+	UploadDirs []string `json:"upload_dirs,omitempty"`
+
+	// CacheTTL はチャンネル名・ユーザー名のディスクキャッシュの有効期間（例: "24h", "30m"）。
+	// 空の場合は 24h、"0" の場合はディスクキャッシュを使用しない。
+	CacheTTL string `json:"cache_ttl,omitempty"`
+
+	// IdempotencyWindow は idempotency_key を記憶する期間（例: "1h", "24h"）。
+	// 空の場合は 1h、"0" の場合は idempotency_key を無視する。
+	IdempotencyWindow string `json:"idempotency_window,omitempty"`
+
+	// ChannelScanMaxPages はチャンネル名の解決で conversations.list を読む最大ページ数（1ページ200件）。
+	// 0（デフォルト）の場合は無制限。
+	ChannelScanMaxPages int `json:"channel_scan_max_pages,omitempty"`
+
+	// WarmChannelIndex が true の場合、MCP Server の起動時にバックグラウンドでチャンネル索引を構築する。
+	// 上位の設定ファイルの true を false で打ち消せるよう、未指定（nil）と区別する。
+	WarmChannelIndex *bool `json:"warm_channel_index,omitempty"`
+
+	// ToolRegistration は MCP Server の起動時にトークンのスコープで使えないツールをどう扱うか
+	// （ToolRegistrationStrict / ToolRegistrationAnnotate / ToolRegistrationAll）。空の場合は annotate。
+	ToolRegistration string `json:"tool_registration,omitempty"`
+
+	// Retry は一時的なエラー（レート制限・5xx・通信エラー）のリトライ方針。
+	// 未設定の項目はデフォルト値（最大4回試行、1s〜30s の指数バックオフ）を使用する。
+	Retry *RetryConfig `json:"retry,omitempty"`
+
+	// RateLimits は Slack API のクライアント側レート制限の上書き。
+	// キーはメソッド名（例: "conversations.history"）または Tier 名（"tier1"〜"tier4"）。
+	// 未指定のメソッドは Slack の Tier ごとの上限をデフォルトとして使用する。
+	RateLimits map[string]RateLimitConfig `json:"rate_limits,omitempty"`
+
+	// Prompts は組み込みのプロンプトに加えて MCP Server に登録するプロンプト。
+	// 組み込みと同じ名前の場合は組み込みのプロンプトを置き換える。
+	Prompts []PromptConfig `json:"prompts,omitempty"`
+
+	// AuthTokens は HTTP / SSE トランスポートで受け付ける Bearer トークン。
+	// 空の場合は認証なしで待ち受ける（stdio では使用しない）。
+	AuthTokens []AuthToken `json:"auth_tokens,omitempty"`
+
+	// Profiles はワークスペースごとの設定（キーはプロファイル名）。
+	// プロファイルで未設定の項目はトップレベルの設定を引き継ぐ。
+	Profiles map[string]*Config `json:"profiles,omitempty"`
+
+	// DefaultProfile はプロファイル未指定時に使用するプロファイル名。
+	// 空の場合はトップレベルの設定を使用する。
+	DefaultProfile string `json:"default_profile,omitempty"`
+
+	// ProfileName は Profile で適用したプロファイル名（トップレベルの場合は空）。
+	ProfileName string `json:"-"`
+
+	// Sources は設定値の読み込み元（読み込んだ順）。doctor コマンドでの表示用。
+	Sources []Source `json:"-"`
+}
+
+// Source は設定値の読み込み元1件分。
+type Source struct {
+	// Kind は読み込み元の種類（SourceGlobal, SourceLocal, SourceFile, SourceEnv, SourceFlag）。
+	Kind string `json:"kind"`
+	// Name はファイルパス・環境変数名・フラグ名。
+	Name string `json:"name"`
+	// Fields はこの読み込み元が値を与えた設定項目（JSON のキー名）。
+	Fields []string `json:"fields"`
+	// Values は Fields の各項目に与えた値（表示用。トークンは MaskSetting でマスキングし、オブジェクト・配列は JSON で表す）。
+	Values map[string]string `json:"values,omitempty"`
+	// Error は読み込みに失敗した場合のエラー（グローバル設定の解析エラーは無視して続行するため記録する）。
+	Error string `json:"error,omitempty"`
+}
+
+// 設定値の読み込み元の種類
+const (
+	SourceGlobal = "global" // グローバル設定ファイル
+	SourceLocal  = "local"  // プロジェクトローカル設定ファイル
+	SourceFile   = "file"   // --config で指定した設定ファイル
+	SourceEnv    = "env"    // 環境変数
+	SourceFlag   = "flag"   // CLI フラグ
+)
+
+// AuthToken は HTTP トランスポートの Bearer トークン1件分の設定。
+type AuthToken struct {
+	// Name はログ出力用の識別名（トークン値そのものはログに出さない）。
+	Name string `json:"name"`
+	// Token はトークン値。${VAR} 形式で環境変数を参照できる。
+	Token string `json:"token"`
+	// Tools は呼び出しを許可するツール名。空の場合は全ツールを許可する。
+	Tools []string `json:"tools,omitempty"`
+}
+
+// PromptConfig は MCP プロンプト1件分の設定。
+// プロンプトの取得時にチャンネルの履歴を取得し、指示文の後ろに埋め込む。
+type PromptConfig struct {
+	// Name はプロンプト名（例: "weekly_report"）。
+	Name string `json:"name"`
+	// Description はプロンプト一覧に表示する説明。
+	Description string `json:"description,omitempty"`
+	// Instructions は指示文。{channel}・{since}・{persona} は引数の値に置き換える。
+	Instructions string `json:"instructions"`
+	// Channel は channel 引数を省略した場合のチャンネル（空の場合は default_channel）。
+	Channel string `json:"channel,omitempty"`
+	// Since は since 引数を省略した場合の取得開始時刻（例: "24h"、"7d"）。空の場合は 24h。
+	Since string `json:"since,omitempty"`
+}
+
+// RetryConfig はリトライ方針の設定。
+type RetryConfig struct {
+	// MaxAttempts は初回を含む最大試行回数（1 でリトライしない）。
+	MaxAttempts int `json:"max_attempts,omitempty"`
+	// BaseBackoff は1回目のリトライ前の待ち時間（例: "500ms"）。以降は2倍ずつ増える。
+	BaseBackoff string `json:"base_backoff,omitempty"`
+	// MaxBackoff は待ち時間の上限（例: "30s"）。
+	MaxBackoff string `json:"max_backoff,omitempty"`
+	// Jitter は待ち時間の揺らぎの割合（0〜1）。nil の場合はデフォルト値。
+	Jitter *float64 `json:"jitter,omitempty"`
+	// RetryOn はリトライするエラー分類（"rate_limited", "server_error", "network"）。
+	RetryOn []string `json:"retry_on,omitempty"`
+}
+
+// RateLimitConfig は API メソッド（または Tier）1つ分のレート制限の設定。
+type RateLimitConfig struct {
+	// PerMinute は1分あたりの呼び出し回数。0 の場合は制限しない。
+	PerMinute int `json:"per_minute"`
+	// Burst は連続して即時に呼び出せる回数。0 の場合は per_minute/10（最低1）。
+	Burst int `json:"burst,omitempty"`
 }
 
 const (
@@ -31,19 +165,31 @@ const (
 	GlobalConfigFile = "config.json"
 )
 
+// tool_registration の値
+const (
+	// ToolRegistrationStrict はスコープが不足しているツールを登録しない。
+	ToolRegistrationStrict = "strict"
+	// ToolRegistrationAnnotate はスコープが不足しているツールの説明に不足スコープを注記する（デフォルト）。
+	ToolRegistrationAnnotate = "annotate"
+	// ToolRegistrationAll はスコープを確認せず全ツールを登録する。
+	ToolRegistrationAll = "all"
+)
+
 // 環境変数名
 const (
-	EnvSlackBotToken        = "SLACK_BOT_TOKEN"
-	EnvSlackDefaultChannel  = "SLACK_DEFAULT_CHANNEL"
-	EnvSlackDisplayName     = "SLACK_DISPLAY_NAME"
-	EnvSlackFastMCPLogLevel = "SLACK_FAST_MCP_LOG_LEVEL"
+	EnvSlackBotToken         = "SLACK_BOT_TOKEN"
+	EnvSlackUserToken        = "SLACK_USER_TOKEN"
+	EnvSlackDefaultChannel   = "SLACK_DEFAULT_CHANNEL"
+	EnvSlackDisplayName      = "SLACK_DISPLAY_NAME"
+	EnvSlackFastMCPLogLevel  = "SLACK_FAST_MCP_LOG_LEVEL"
+	EnvSlackFastMCPLogFormat = "SLACK_FAST_MCP_LOG_FORMAT"
 )
 
 // envVarPattern は ${VAR_NAME} 形式の環境変数参照を検出する正規表現。
 var envVarPattern = regexp.MustCompile(`\$\{([A-Z_][A-Z0-9_]*)\}`)
 
 // tokenPattern はトークン直書きを検出する正規表現。
-var tokenPattern = regexp.MustCompile(`^xox[bps]-`)
+var tokenPattern = regexp.MustCompile(`^(xoxe\.)?(xox[a-z]|xapp)-`)
 
 // Load はプロジェクトディレクトリから設定を読み込む。
 // 読み込み順序: グローバル設定 → ローカル設定（上書き） → 環境変数（上書き）
@@ -55,16 +201,23 @@ func Load(projectDir string) (*Config, error) {
 	// 1. グローバル設定ファイル読み込み
 	globalPath, err := globalConfigPath()
 	if err == nil {
-		if gcfg, err := loadFromFile(globalPath); err == nil {
+		if gcfg, src, err := loadFromFile(globalPath); err == nil {
 			mergeConfig(cfg, gcfg)
+			src.Kind = SourceGlobal
+			cfg.Sources = append(cfg.Sources, src)
+		} else if !os.IsNotExist(err) {
+			// 解析できないグローバル設定は無視して続行する（doctor で表示するため記録のみ）
+			cfg.Sources = append(cfg.Sources, Source{Kind: SourceGlobal, Name: globalPath, Error: err.Error()})
 		}
 		// グローバル設定がなくてもエラーにしない
 	}
 
 	// 2. プロジェクトローカル設定ファイル読み込み
 	localPath := filepath.Join(projectDir, LocalConfigFile)
-	if lcfg, err := loadFromFile(localPath); err == nil {
+	if lcfg, src, err := loadFromFile(localPath); err == nil {
 		mergeConfig(cfg, lcfg)
+		src.Kind = SourceLocal
+		cfg.Sources = append(cfg.Sources, src)
 	} else if !os.IsNotExist(err) {
 		// ファイルが存在するがパースエラーの場合
 		return nil, apperr.New(apperr.CodeConfigParseError,
@@ -72,23 +225,10 @@ func Load(projectDir string) (*Config, error) {
 	}
 
 	// 3. トークンの環境変数展開（${VAR} 形式）
-	cfg.Token = expandEnvVars(cfg.Token)
-	cfg.DefaultChannel = expandEnvVars(cfg.DefaultChannel)
-	cfg.DisplayName = expandEnvVars(cfg.DisplayName)
+	cfg.expandEnv()
 
 	// 4. 環境変数で上書き
-	if v := os.Getenv(EnvSlackBotToken); v != "" {
-		cfg.Token = v
-	}
-	if v := os.Getenv(EnvSlackDefaultChannel); v != "" {
-		cfg.DefaultChannel = v
-	}
-	if v := os.Getenv(EnvSlackDisplayName); v != "" {
-		cfg.DisplayName = v
-	}
-	if v := os.Getenv(EnvSlackFastMCPLogLevel); v != "" {
-		cfg.LogLevel = v
-	}
+	cfg.applyEnv()
 
 	// 5. トークン直書き検出（警告のみ）
 	if tokenPattern.MatchString(cfg.Token) {
@@ -106,43 +246,188 @@ func Load(projectDir string) (*Config, error) {
 
 // LoadFromPath は指定パスの設定ファイルのみを読み込む（CLI の --config 用）。
 func LoadFromPath(path string) (*Config, error) {
-	cfg, err := loadFromFile(path)
+	cfg, src, err := loadFromFile(path)
 	if err != nil {
 		return nil, apperr.New(apperr.CodeConfigParseError,
 			fmt.Sprintf("設定ファイルの解析に失敗しました: %s", path), err)
 	}
+	src.Kind = SourceFile
+	cfg.Sources = []Source{src}
 
 	// 環境変数展開
-	cfg.Token = expandEnvVars(cfg.Token)
-	cfg.DefaultChannel = expandEnvVars(cfg.DefaultChannel)
-	cfg.DisplayName = expandEnvVars(cfg.DisplayName)
+	cfg.expandEnv()
 
 	// 環境変数で上書き
-	if v := os.Getenv(EnvSlackBotToken); v != "" {
-		cfg.Token = v
+	cfg.applyEnv()
+
+	return cfg, nil
+}
+
+// envOverrides は設定を上書きする環境変数と対応する設定項目。
+var envOverrides = []struct {
+	name  string
+	field string
+	set   func(c *Config, v string)
+}{
+	{EnvSlackBotToken, "token", func(c *Config, v string) { c.Token = v }},
+	{EnvSlackUserToken, "user_token", func(c *Config, v string) { c.UserToken = v }},
+	{EnvSlackDefaultChannel, "default_channel", func(c *Config, v string) { c.DefaultChannel = v }},
+	{EnvSlackDisplayName, "display_name", func(c *Config, v string) { c.DisplayName = v }},
+	{EnvSlackFastMCPLogLevel, "log_level", func(c *Config, v string) { c.LogLevel = v }},
+	{EnvSlackFastMCPLogFormat, "log_format", func(c *Config, v string) { c.LogFormat = v }},
+}
+
+// applyEnv は環境変数で設定を上書きし、読み込み元を記録する。
+func (c *Config) applyEnv() {
+	for _, e := range envOverrides {
+		if v := os.Getenv(e.name); v != "" {
+			e.set(c, v)
+			c.Sources = append(c.Sources, Source{Kind: SourceEnv, Name: e.name, Fields: []string{e.field},
+				Values: map[string]string{e.field: MaskSetting(e.field, v)}})
+		}
 	}
-	if v := os.Getenv(EnvSlackDefaultChannel); v != "" {
-		cfg.DefaultChannel = v
+}
+
+// Validate は設定の必須項目を検証する。
+// プロファイルがある場合は、プロファイル未指定時に使用する設定と全プロファイルを検証する。
+func (c *Config) Validate() error {
+	if len(c.Profiles) == 0 && c.DefaultProfile == "" {
+		return c.validate()
 	}
-	if v := os.Getenv(EnvSlackDisplayName); v != "" {
-		cfg.DisplayName = v
+
+	names := c.ProfileNames()
+	if c.DefaultProfile == "" {
+		names = append([]string{""}, names...)
+	} else if _, err := c.Profile(c.DefaultProfile); err != nil {
+		return err
 	}
-	if v := os.Getenv(EnvSlackFastMCPLogLevel); v != "" {
-		cfg.LogLevel = v
+	for _, name := range names {
+		if p := c.Profiles[name]; p != nil && (len(p.Profiles) > 0 || p.DefaultProfile != "" || len(p.Prompts) > 0) {
+			return apperr.New(apperr.CodeConfigParseError,
+				fmt.Sprintf("プロファイル %s: profiles / default_profile / prompts はプロファイル内に指定できません", name), nil)
+		}
+		p, err := c.Profile(name)
+		if err != nil {
+			return err
+		}
+		if err := p.validate(); err != nil {
+			if appErr, ok := err.(*apperr.AppError); ok && name != "" {
+				return apperr.New(appErr.Code, fmt.Sprintf("プロファイル %s: %s", name, appErr.Message), appErr.Err)
+			}
+			return err
+		}
 	}
+	return nil
+}
 
-	return cfg, nil
+// ProfileNames は設定済みのプロファイル名を名前順に返す。
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-// Validate は設定の必須項目を検証する。
-func (c *Config) Validate() error {
+// Profile は name のプロファイルをトップレベルの設定に重ねた設定を返す（mergeConfig と同じ規則）。
+// name が空の場合はトップレベルの設定を返す。
+func (c *Config) Profile(name string) (*Config, error) {
+	out := *c
+	out.Profiles = nil
+	out.DefaultProfile = ""
+	if name == "" {
+		return &out, nil
+	}
+
+	p := c.Profiles[name]
+	if p == nil {
+		configured := "なし"
+		if names := c.ProfileNames(); len(names) > 0 {
+			configured = strings.Join(names, ", ")
+		}
+		return nil, apperr.New(apperr.CodeProfileNotFound,
+			fmt.Sprintf("プロファイルが見つかりません: %s（設定済み: %s）", name, configured), nil)
+	}
+	mergeConfig(&out, p)
+	out.Profiles = nil
+	out.DefaultProfile = ""
+	out.ProfileName = name
+	return &out, nil
+}
+
+// validate は1つのワークスペース分の設定を検証する。
+func (c *Config) validate() error {
 	if c.Token == "" {
 		return apperr.New(apperr.CodeTokenNotConfigured,
 			"トークンが設定されていません", nil)
 	}
+	if _, err := ParseDuration(c.CacheTTL, 0); err != nil {
+		return apperr.New(apperr.CodeConfigParseError,
+			fmt.Sprintf("cache_ttl の形式が不正です: %s", c.CacheTTL), err)
+	}
+	if _, err := ParseDuration(c.IdempotencyWindow, 0); err != nil {
+		return apperr.New(apperr.CodeConfigParseError,
+			fmt.Sprintf("idempotency_window の形式が不正です: %s", c.IdempotencyWindow), err)
+	}
+	switch c.ToolRegistration {
+	case "", ToolRegistrationStrict, ToolRegistrationAnnotate, ToolRegistrationAll:
+	default:
+		return apperr.New(apperr.CodeConfigParseError,
+			fmt.Sprintf("tool_registration の値が不正です: %s (strict / annotate / all)", c.ToolRegistration), nil)
+	}
+	return c.validatePrompts()
+}
+
+// validatePrompts は prompts の設定を検証する（名前・指示文は必須、名前の重複不可）。
+// since の形式はプロンプトを登録する側（mcp パッケージ）で検証する。
+func (c *Config) validatePrompts() error {
+	seen := make(map[string]bool, len(c.Prompts))
+	for i, p := range c.Prompts {
+		if p.Name == "" || strings.TrimSpace(p.Instructions) == "" {
+			return apperr.New(apperr.CodeConfigParseError,
+				fmt.Sprintf("prompts[%d]: name と instructions は必須です", i), nil)
+		}
+		if seen[p.Name] {
+			return apperr.New(apperr.CodeConfigParseError,
+				fmt.Sprintf("prompts: 名前が重複しています: %s", p.Name), nil)
+		}
+		seen[p.Name] = true
+	}
 	return nil
 }
 
+// WarmChannelIndexEnabled は起動時にチャンネル索引を構築するかを返す（未設定の場合は false）。
+func (c *Config) WarmChannelIndexEnabled() bool {
+	return c.WarmChannelIndex != nil && *c.WarmChannelIndex
+}
+
+// ToolRegistrationMode は tool_registration の値を返す（未設定の場合は annotate）。
+func (c *Config) ToolRegistrationMode() string {
+	if c.ToolRegistration == "" {
+		return ToolRegistrationAnnotate
+	}
+	return c.ToolRegistration
+}
+
+// ParseDuration は期間の設定値（cache_ttl 等）を解析する。空の場合は def、"0" の場合は 0 を返す。
+func ParseDuration(s string, def time.Duration) (time.Duration, error) {
+	switch s {
+	case "":
+		return def, nil
+	case "0":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration")
+	}
+	return d, nil
+}
+
 // ResolveChannel はチャンネルを解決する。
 // パラメータ指定 > デフォルトチャンネル の優先順位。
 func (c *Config) ResolveChannel(channel string) (string, error) {
@@ -165,19 +450,82 @@ func (c *Config) ResolveDisplayName(displayName string) string {
 	return c.DisplayName
 }
 
-// loadFromFile はJSONファイルからConfigを読み込む。
-func loadFromFile(path string) (*Config, error) {
+// loadFromFile はJSONファイルからConfigを読み込み、値が設定されている項目と値を読み込み元として返す（Kind は呼び出し側で設定する）。
+func loadFromFile(path string) (*Config, Source, error) {
+	src := Source{Name: path}
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, err
+		return nil, src, err
 	}
 
 	var cfg Config
 	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, err
+		return nil, src, err
+	}
+
+	var raw map[string]json.RawMessage
+	_ = json.Unmarshal(data, &raw)
+	src.Fields = make([]string, 0, len(raw))
+	src.Values = make(map[string]string, len(raw))
+	for key, value := range raw {
+		if string(value) == "null" {
+			continue
+		}
+		src.Fields = append(src.Fields, key)
+		var v any
+		_ = json.Unmarshal(value, &v)
+		src.Values[key] = settingString(key, v)
 	}
+	sort.Strings(src.Fields)
 
-	return &cfg, nil
+	return &cfg, src, nil
+}
+
+// secretSettings は値をマスキングして表示する設定項目（auth_tokens・profiles 内の同名のキーも対象）。
+var secretSettings = map[string]bool{"token": true, "user_token": true}
+
+// MaskSetting は設定項目 key の値 value を表示用に返す。トークンは apperr.MaskToken でマスキングし、
+// Slack トークン以外の形式の値も伏せる（${VAR} 形式の環境変数参照はそのまま表示する）。
+func MaskSetting(key, value string) string {
+	if !secretSettings[key] || value == "" || envVarPattern.FindString(value) == value {
+		return value
+	}
+	if masked := apperr.MaskToken(value); masked != value {
+		return masked
+	}
+	return "****"
+}
+
+// settingString は設定ファイルの値 v を表示用の文字列にする。文字列以外は JSON で表し、
+// 入れ子のトークン（auth_tokens・profiles の token 等）もマスキングする。
+func settingString(key string, v any) string {
+	if s, ok := v.(string); ok {
+		return MaskSetting(key, s)
+	}
+	data, err := json.Marshal(maskSecrets(v))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// maskSecrets は JSON の値 v に含まれる secretSettings のキーの文字列値をマスキングする。
+func maskSecrets(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s, ok := value.(string); ok {
+				v[key] = MaskSetting(key, s)
+			} else {
+				v[key] = maskSecrets(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = maskSecrets(value)
+		}
+	}
+	return v
 }
 
 // mergeConfig は src の非空フィールドで dst を上書きする。
@@ -185,6 +533,9 @@ func mergeConfig(dst, src *Config) {
 	if src.Token != "" {
 		dst.Token = src.Token
 	}
+	if src.UserToken != "" {
+		dst.UserToken = src.UserToken
+	}
 	if src.DefaultChannel != "" {
 		dst.DefaultChannel = src.DefaultChannel
 	}
@@ -194,6 +545,75 @@ func mergeConfig(dst, src *Config) {
 	if src.LogLevel != "" {
 		dst.LogLevel = src.LogLevel
 	}
+	if src.LogFormat != "" {
+		dst.LogFormat = src.LogFormat
+	}
+	if len(src.UploadDirs) > 0 {
+		dst.UploadDirs = src.UploadDirs
+	}
+	if src.CacheTTL != "" {
+		dst.CacheTTL = src.CacheTTL
+	}
+	if src.IdempotencyWindow != "" {
+		dst.IdempotencyWindow = src.IdempotencyWindow
+	}
+	if src.ChannelScanMaxPages != 0 {
+		dst.ChannelScanMaxPages = src.ChannelScanMaxPages
+	}
+	if src.WarmChannelIndex != nil {
+		dst.WarmChannelIndex = src.WarmChannelIndex
+	}
+	if src.ToolRegistration != "" {
+		dst.ToolRegistration = src.ToolRegistration
+	}
+	if src.Retry != nil {
+		dst.Retry = src.Retry
+	}
+	if len(src.RateLimits) > 0 {
+		dst.RateLimits = src.RateLimits
+	}
+	if len(src.Prompts) > 0 {
+		dst.Prompts = src.Prompts
+	}
+	if len(src.AuthTokens) > 0 {
+		dst.AuthTokens = src.AuthTokens
+	}
+	// プロファイルは名前ごとに同じ規則でマージする
+	for name, p := range src.Profiles {
+		if p == nil {
+			continue
+		}
+		if dst.Profiles == nil {
+			dst.Profiles = make(map[string]*Config)
+		}
+		if existing := dst.Profiles[name]; existing != nil {
+			mergeConfig(existing, p)
+		} else {
+			dst.Profiles[name] = p
+		}
+	}
+	if src.DefaultProfile != "" {
+		dst.DefaultProfile = src.DefaultProfile
+	}
+}
+
+// expandEnv は設定値中の ${VAR_NAME} を環境変数の値に展開する。
+func (c *Config) expandEnv() {
+	c.Token = expandEnvVars(c.Token)
+	c.UserToken = expandEnvVars(c.UserToken)
+	c.DefaultChannel = expandEnvVars(c.DefaultChannel)
+	c.DisplayName = expandEnvVars(c.DisplayName)
+	for i := range c.UploadDirs {
+		c.UploadDirs[i] = expandEnvVars(c.UploadDirs[i])
+	}
+	for i := range c.AuthTokens {
+		c.AuthTokens[i].Token = expandEnvVars(c.AuthTokens[i].Token)
+	}
+	for _, p := range c.Profiles {
+		if p != nil {
+			p.expandEnv()
+		}
+	}
 }
 
 // expandEnvVars は文字列中の ${VAR_NAME} を環境変数の値に展開する。
@@ -1,18 +1,24 @@
 // Package errors defines application-level error types for slack-fast-mcp.
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 // AppError はアプリケーションエラーを表す構造体。
 // Code: エラーコード（channel_not_found 等）
 // Message: 人間向けメッセージ
 // Hint: LLM向けの解決ヒント（英語）
+// Suggestions: 入力値の修正候補（channel_not_found 時の近いチャンネル名等）
 // Err: 元のエラー
 type AppError struct {
-	Code    string
-	Message string
-	Hint    string
-	Err     error
+	Code        string
+	Message     string
+	Hint        string
+	Suggestions []string
+	Err         error
 }
 
 // Error implements the error interface.
@@ -30,38 +36,74 @@ func (e *AppError) Unwrap() error {
 
 // エラーコード定数
 const (
-	CodeChannelNotFound    = "channel_not_found"
-	CodeNotInChannel       = "not_in_channel"
-	CodeInvalidAuth        = "invalid_auth"
-	CodeMissingScope       = "missing_scope"
-	CodeNoText             = "no_text"
-	CodeNoDefaultChannel   = "no_default_channel"
-	CodeThreadNotFound     = "thread_not_found"
-	CodeRateLimited        = "rate_limited"
-	CodeTokenNotConfigured = "token_not_configured"
-	CodeConfigParseError   = "config_parse_error"
-	CodeNetworkError       = "network_error"
-	CodeAlreadyReacted     = "already_reacted"
-	CodeNoReaction         = "no_reaction"
-	CodeInvalidReaction    = "invalid_reaction"
+	CodeChannelNotFound          = "channel_not_found"
+	CodeNotInChannel             = "not_in_channel"
+	CodeInvalidAuth              = "invalid_auth"
+	CodeMissingScope             = "missing_scope"
+	CodeNoText                   = "no_text"
+	CodeNoDefaultChannel         = "no_default_channel"
+	CodeThreadNotFound           = "thread_not_found"
+	CodeRateLimited              = "rate_limited"
+	CodeTokenNotConfigured       = "token_not_configured"
+	CodeConfigParseError         = "config_parse_error"
+	CodeNetworkError             = "network_error"
+	CodeAlreadyReacted           = "already_reacted"
+	CodeNoReaction               = "no_reaction"
+	CodeInvalidReaction          = "invalid_reaction"
+	CodeToolNotAllowed           = "tool_not_allowed"
+	CodeMessageNotFound          = "message_not_found"
+	CodeCantUpdateMessage        = "cant_update_message"
+	CodeCantDeleteMessage        = "cant_delete_message"
+	CodeInvalidBlocks            = "invalid_blocks"
+	CodeNoFileContent            = "no_file_content"
+	CodePathNotAllowed           = "path_not_allowed"
+	CodeFileReadError            = "file_read_error"
+	CodeNoQuery                  = "no_query"
+	CodeInvalidSort              = "invalid_sort"
+	CodeUserTokenNotConfigured   = "user_token_not_configured"
+	CodeUserNotFound             = "user_not_found"
+	CodeInvalidPostAt            = "invalid_post_at"
+	CodeInvalidSince             = "invalid_since"
+	CodeScheduledMessageNotFound = "scheduled_message_not_found"
+	CodeIdempotencyConflict      = "idempotency_conflict"
+	CodeIdempotencyInProgress    = "idempotency_in_progress"
+	CodeProfileNotFound          = "profile_not_found"
 )
 
 // エラーHintマップ（LLM向け・英語）
 var hintMap = map[string]string{
-	CodeChannelNotFound:    "The channel was not found. Ask the user to verify the channel name or ID. Do not include the '#' prefix.",
-	CodeNotInChannel:       "The bot is not a member of this channel. Ask the user to invite the bot by running: /invite @slack-fast-mcp",
-	CodeInvalidAuth:        "The Slack token is invalid or expired. Ask the user to regenerate the token at https://api.slack.com/apps",
-	CodeMissingScope:       "Required OAuth scope is missing. Ask the user to add the missing scope in Slack App settings and reinstall the app.",
-	CodeNoText:             "The message parameter is required and cannot be empty.",
-	CodeNoDefaultChannel:   "No channel specified and no default_channel configured. Set default_channel in config or specify the channel parameter.",
-	CodeThreadNotFound:     "The thread_ts does not match any existing message. Ask the user to verify the thread timestamp.",
-	CodeRateLimited:        "Slack API rate limit reached. The tool will automatically retry. If this persists, wait a moment and try again.",
-	CodeTokenNotConfigured: "No Slack token found. Ask the user to run 'slack-fast-mcp setup' or set the SLACK_BOT_TOKEN environment variable.",
-	CodeConfigParseError:   "Failed to parse config file. Ask the user to verify the JSON syntax in .slack-mcp.json",
-	CodeNetworkError:       "Failed to connect to Slack API. Check network connectivity and try again.",
-	CodeAlreadyReacted:     "The bot has already reacted with this emoji. Use a different emoji or remove the existing reaction first.",
-	CodeNoReaction:         "The bot has not reacted with this emoji, so it cannot be removed. Check the emoji name and try again.",
-	CodeInvalidReaction:    "The reaction emoji name is invalid. Use emoji names without colons (e.g. 'thumbsup', not ':thumbsup:').",
+	CodeChannelNotFound:          "The channel was not found. Ask the user to verify the channel name or ID. Do not include the '#' prefix.",
+	CodeNotInChannel:             "The bot is not a member of this channel. Ask the user to invite the bot by running: /invite @slack-fast-mcp",
+	CodeInvalidAuth:              "The Slack token is invalid or expired. Ask the user to regenerate the token at https://api.slack.com/apps",
+	CodeMissingScope:             "Required OAuth scope is missing. Ask the user to add the missing scope in Slack App settings and reinstall the app.",
+	CodeNoText:                   "The message parameter is required and cannot be empty.",
+	CodeNoDefaultChannel:         "No channel specified and no default_channel configured. Set default_channel in config or specify the channel parameter.",
+	CodeThreadNotFound:           "The thread_ts does not match any existing message. Ask the user to verify the thread timestamp.",
+	CodeRateLimited:              "Slack API rate limit reached. The tool will automatically retry. If this persists, wait a moment and try again.",
+	CodeTokenNotConfigured:       "No Slack token found. Ask the user to run 'slack-fast-mcp setup' or set the SLACK_BOT_TOKEN environment variable.",
+	CodeConfigParseError:         "Failed to parse config file. Ask the user to verify the JSON syntax in .slack-mcp.json",
+	CodeNetworkError:             "Failed to connect to Slack API. Check network connectivity and try again.",
+	CodeAlreadyReacted:           "The bot has already reacted with this emoji. Use a different emoji or remove the existing reaction first.",
+	CodeNoReaction:               "The bot has not reacted with this emoji, so it cannot be removed. Check the emoji name and try again.",
+	CodeInvalidReaction:          "The reaction emoji name is invalid. Use emoji names without colons (e.g. 'thumbsup', not ':thumbsup:').",
+	CodeNoQuery:                  "The query parameter is required and cannot be empty.",
+	CodeInvalidSort:              "sort must be 'score' or 'timestamp', and sort_dir must be 'desc' or 'asc'. Omit them to sort by relevance, newest first.",
+	CodeUserTokenNotConfigured:   "Message search requires a Slack user token (xoxp-) with the search:read scope; bot tokens cannot search. Ask the user to set user_token in config or the SLACK_USER_TOKEN environment variable.",
+	CodeUserNotFound:             "No reachable Slack user matches this recipient. Use the exact username (e.g. '@alice'), the user's email address, or their user ID (U...). Email lookup needs the users:read.email scope; bots and deactivated users cannot receive DMs.",
+	CodeInvalidPostAt:            "post_at must be a future time within 120 days: a Unix timestamp (e.g. '1767225600'), RFC3339 (e.g. '2026-01-01T09:00:00+09:00'), or a relative duration (e.g. 'in 2h', 'in 1d').",
+	CodeInvalidSince:             "since and until must be past times (until after since): a relative duration (e.g. '24h', '7d'), a date (e.g. '2026-01-01'), RFC3339 (e.g. '2026-01-01T09:00:00+09:00'), or a Unix timestamp.",
+	CodeScheduledMessageNotFound: "The scheduled message does not exist, has already been sent, or belongs to another channel. Use slack_list_scheduled_messages to find the ID and channel.",
+	CodeIdempotencyConflict:      "This idempotency_key was already used for a different message. Resend the original parameters to get the original result, or use a new key for a new message.",
+	CodeIdempotencyInProgress:    "Another call is still posting with this idempotency_key. Wait a few seconds, then resend the same parameters to get its result.",
+	CodeProfileNotFound:          "No workspace profile with this name is configured. Use one of the profile names listed in the error message, or omit 'workspace' to use the default workspace.",
+	CodeMessageNotFound:          "No message exists at this timestamp in the channel. Verify the channel and the 'ts' value returned when the message was posted.",
+	CodeCantUpdateMessage:        "This message cannot be edited. The bot can only edit its own messages, and the workspace may restrict the edit window.",
+	CodeCantDeleteMessage:        "This message cannot be deleted. The bot can only delete its own messages.",
+	CodeInvalidBlocks:            "The blocks parameter is not a valid Block Kit JSON array. Check each block's 'type' and required fields (see https://api.slack.com/block-kit), or omit blocks to post plain text.",
+	CodeNoFileContent:            "Provide exactly one of 'content' (inline text) or 'path' (local file). Empty files cannot be uploaded.",
+	CodePathNotAllowed:           "Local file uploads are restricted to the directories listed in upload_dirs in the config. Ask the user to add the directory, or pass the file text via 'content' instead.",
+	CodeFileReadError:            "The local file could not be read. Verify that the path exists and is a regular file.",
+	CodeToolNotAllowed:           "The access token used for this MCP connection is not allowed to call this tool. Ask the server operator to add the tool to the token's allow-list.",
 }
 
 // New は指定されたコードでAppErrorを生成する。
@@ -86,22 +128,31 @@ func NewWithHint(code, message, hint string, err error) *AppError {
 	}
 }
 
+// WithSuggestions は修正候補を設定して自身を返す。
+func (e *AppError) WithSuggestions(suggestions []string) *AppError {
+	e.Suggestions = suggestions
+	return e
+}
+
 // FormatForMCP はMCPツールエラーメッセージとしてフォーマットする。
+// 修正候補がある場合は "Did you mean" 行を追加し、LLM が追加の問い合わせなしに再試行できるようにする。
 func (e *AppError) FormatForMCP() string {
-	return fmt.Sprintf("Error [%s]: %s\nHint: %s", e.Code, e.Message, e.Hint)
+	msg := fmt.Sprintf("Error [%s]: %s\nHint: %s", e.Code, e.Message, e.Hint)
+	if len(e.Suggestions) > 0 {
+		msg += "\nDid you mean: " + strings.Join(e.Suggestions, ", ")
+	}
+	return msg
 }
 
+// tokenPrefixPattern は Slack トークンの種類を表す接頭辞（xoxb-・xoxp-・xoxa-・xoxe-・xoxr-・xapp- 等）に一致する正規表現。
+// ローテーションするトークンの xoxe.xoxp- 形式も含む。
+var tokenPrefixPattern = regexp.MustCompile(`^(xoxe\.)?(xox[a-z]|xapp)-`)
+
 // MaskToken はトークン文字列をマスキングする。
 // xoxb-xxxx → xoxb-****
 func MaskToken(s string) string {
-	if len(s) < 5 {
-		return s
-	}
-	prefixes := []string{"xoxb-", "xoxp-", "xoxs-"}
-	for _, prefix := range prefixes {
-		if len(s) >= len(prefix) && s[:len(prefix)] == prefix {
-			return prefix + "****"
-		}
+	if prefix := tokenPrefixPattern.FindString(s); prefix != "" {
+		return prefix + "****"
 	}
 	return s
 }
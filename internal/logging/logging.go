@@ -0,0 +1,83 @@
+// Package logging provides the structured logger shared by the MCP server, CLI and Slack client.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+)
+
+const (
+	// FormatText は key=value 形式のログ出力。
+	FormatText = "text"
+
+	// FormatJSON は1行1 JSON オブジェクトのログ出力。
+	FormatJSON = "json"
+)
+
+// tokenInTextPattern は文字列中に埋め込まれた Slack トークン（リクエストボディ等）を検出する正規表現。
+// xoxb-（Bot）・xoxp-（ユーザー）のほか、xoxa-・xoxe-・xoxr-・xapp-（アプリレベル）等の種類も対象にする。
+var tokenInTextPattern = regexp.MustCompile(`(xoxe\.)?(xox[a-z]|xapp)-[A-Za-z0-9-]+`)
+
+// New は level・format に従って w（通常は stderr）へ出力する Logger を作成する。
+// 空の level は warn、空の format は text として扱う。
+// stdout は MCP プロトコル・コマンド結果の出力専用のため、w に stdout を渡さないこと。
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl, ReplaceAttr: maskAttr}
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q (want text or json)", format)
+}
+
+// Discard は何も出力しない Logger を返す（ロガー未設定時のデフォルト）。
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// ParseLevel はログレベル名（debug / info / warn / error）を slog.Level に変換する。
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "", "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", s)
+}
+
+// MaskTokens は文字列中の Slack トークンを MaskToken でマスキングする。
+func MaskTokens(s string) string {
+	return tokenInTextPattern.ReplaceAllStringFunc(s, apperr.MaskToken)
+}
+
+// maskAttr はログ出力前に文字列・エラー値に含まれるトークンをマスキングする（メッセージ本文を含む）。
+func maskAttr(groups []string, a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindString:
+		if s := a.Value.String(); tokenInTextPattern.MatchString(s) {
+			a.Value = slog.StringValue(MaskTokens(s))
+		}
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			a.Value = slog.StringValue(MaskTokens(err.Error()))
+		}
+	}
+	return a
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNew_LevelAndFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Debug("hidden")
+	logger.Info("tool call", "tool", "slack_post_message")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines, want 1 (debug suppressed): %q", len(lines), buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if entry["msg"] != "tool call" || entry["tool"] != "slack_post_message" || entry["level"] != "INFO" {
+		t.Errorf("entry = %v", entry)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "verbose", "text"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+	if _, err := New(&bytes.Buffer{}, "warn", "yaml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestNew_MasksTokens(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&buf, "debug", "text")
+
+	logger.Debug("request token=xoxb-1234-abcd",
+		"body", "token=xoxp-9999-zzzz&channel=C123",
+		"error", errors.New("invalid_auth for xoxb-1234-abcd"),
+		"refresh", "refresh_token=xoxe-1-refresh&client_id=1",
+		"tokens", "xoxa-2-legacy xoxr-5678-old xapp-1-A0123-app xoxe.xoxp-1-rotated",
+	)
+
+	out := buf.String()
+	for _, secret := range []string{"xoxb-1234-abcd", "xoxp-9999-zzzz", "xoxe-1-refresh", "xoxa-2-legacy", "xoxr-5678-old", "xapp-1-A0123-app", "xoxp-1-rotated"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("output leaks %q: %s", secret, out)
+		}
+	}
+	if !strings.Contains(out, "xoxb-****") || !strings.Contains(out, "channel=C123") {
+		t.Errorf("output = %s, want masked token and other fields intact", out)
+	}
+}
@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// allowedToolsKey は認証済みトークンの許可ツール集合を context に格納するキー。
+type allowedToolsKey struct{}
+
+// withAllowedTools は許可ツール集合を context に設定する。
+// nil の場合は全ツールを許可する（スコープ指定のないトークン）。
+func withAllowedTools(ctx context.Context, tools map[string]bool) context.Context {
+	return context.WithValue(ctx, allowedToolsKey{}, tools)
+}
+
+// isToolAllowed は context の許可ツール集合に name が含まれるかを判定する。
+// stdio 接続や認証なしの HTTP 接続では context に値がないため常に true を返す。
+func isToolAllowed(ctx context.Context, name string) bool {
+	tools, ok := ctx.Value(allowedToolsKey{}).(map[string]bool)
+	if !ok || tools == nil {
+		return true
+	}
+	return tools[name]
+}
+
+// filterAllowedTools は tools/list の結果を許可ツールのみに絞り込む。
+func filterAllowedTools(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if isToolAllowed(ctx, tool.Name) {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered
+}
+
+// requireAllowedTool は許可されていないツールの呼び出しを拒否するミドルウェア。
+func requireAllowedTool(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !isToolAllowed(ctx, request.Params.Name) {
+			appErr := apperr.New(apperr.CodeToolNotAllowed,
+				"このトークンでは呼び出せないツールです: "+request.Params.Name, nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+		return next(ctx, request)
+	}
+}
+
+// authToken は照合用に前処理した Bearer トークン。
+type authToken struct {
+	name string
+	// digest はトークンの SHA-256。長さの違いが比較時間に表れないよう、ダイジェスト同士を比較する。
+	digest [sha256.Size]byte
+	tools  map[string]bool
+}
+
+// newAuthTokens は設定値を検証し、照合用のトークン一覧に変換する。
+// 空のトークン（未設定の環境変数参照など）や未登録のツール名はエラーにする。
+func newAuthTokens(s *server.MCPServer, tokens []config.AuthToken) ([]authToken, error) {
+	result := make([]authToken, 0, len(tokens))
+	for i, t := range tokens {
+		name := t.Name
+		if name == "" {
+			name = fmt.Sprintf("auth_tokens[%d]", i)
+		}
+		if t.Token == "" {
+			return nil, fmt.Errorf("auth token %q is empty (check that the referenced environment variable is set)", name)
+		}
+
+		var tools map[string]bool
+		if len(t.Tools) > 0 {
+			tools = make(map[string]bool, len(t.Tools))
+			for _, tool := range t.Tools {
+				if s.GetTool(tool) == nil {
+					return nil, fmt.Errorf("auth token %q allows unknown tool %q", name, tool)
+				}
+				tools[tool] = true
+			}
+		}
+
+		result = append(result, authToken{name: name, digest: sha256.Sum256([]byte(t.Token)), tools: tools})
+	}
+	return result, nil
+}
+
+// withBearerAuth は Authorization: Bearer ヘッダを検証するミドルウェア。
+// 一致したトークンの許可ツール集合を context に設定して next に渡す。
+func withBearerAuth(next http.Handler, tokens []authToken) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r)
+		if !ok {
+			writeUnauthorized(w)
+			return
+		}
+
+		matched := matchToken(tokens, token)
+		if matched == nil {
+			writeUnauthorized(w)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withAllowedTools(r.Context(), matched.tools)))
+	})
+}
+
+// matchToken はトークンの SHA-256 を定数時間比較で照合する。
+// トークンの長さやどのトークンに一致したかで処理時間が変わらないよう、固定長のダイジェストを全件比較する。
+func matchToken(tokens []authToken, presented []byte) *authToken {
+	digest := sha256.Sum256(presented)
+	var matched *authToken
+	for i := range tokens {
+		if subtle.ConstantTimeCompare(tokens[i].digest[:], digest[:]) == 1 && matched == nil {
+			matched = &tokens[i]
+		}
+	}
+	return matched
+}
+
+// bearerToken は Authorization ヘッダから Bearer トークンを取り出す。
+func bearerToken(r *http.Request) ([]byte, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, false
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, false
+	}
+	return []byte(token), true
+}
+
+// writeUnauthorized は 401 応答を返す。
+func writeUnauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="slack-fast-mcp"`)
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
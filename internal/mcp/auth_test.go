@@ -0,0 +1,137 @@
+package mcp
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// newAuthTestServer は Bearer 認証付きの Streamable HTTP テストサーバーを起動する。
+func newAuthTestServer(t *testing.T, tokens []config.AuthToken) *httptest.Server {
+	t.Helper()
+	s := NewServerWithClient(&config.Config{DefaultChannel: "general"}, &slackclient.MockClient{})
+	handler, err := NewHTTPHandler(s, HTTPOptions{Transport: TransportHTTP, AuthTokens: tokens})
+	if err != nil {
+		t.Fatalf("NewHTTPHandler failed: %v", err)
+	}
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// postMCP は Authorization ヘッダ付きで JSON-RPC リクエストを送信する。
+func postMCP(t *testing.T, url, token, body string) (int, string) {
+	t.Helper()
+	status, respBody, _ := postMCPSession(t, url, token, "", body)
+	return status, respBody
+}
+
+// postMCPSession はセッションID付きで JSON-RPC リクエストを送信し、応答のセッションIDも返す。
+func postMCPSession(t *testing.T, url, token, sessionID, body string) (int, string, string) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url+"/mcp", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if sessionID != "" {
+		req.Header.Set(server.HeaderKeySessionID, sessionID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(b), resp.Header.Get(server.HeaderKeySessionID)
+}
+
+// --- A01: トークンなし / 不一致は 401 ---
+func TestBearerAuth_Unauthorized(t *testing.T) {
+	ts := newAuthTestServer(t, []config.AuthToken{{Name: "ci", Token: "secret-1"}})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"no token", ""},
+		{"wrong token", "secret-2"},
+		{"prefix of token", "secret"},
+		{"token with suffix", "secret-10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _ := postMCP(t, ts.URL, tt.token, initializeRequest)
+			if status != http.StatusUnauthorized {
+				t.Errorf("status = %d, want 401", status)
+			}
+		})
+	}
+}
+
+// --- A02: 正しいトークンで initialize 成功 ---
+func TestBearerAuth_Authorized(t *testing.T) {
+	ts := newAuthTestServer(t, []config.AuthToken{
+		{Name: "ci", Token: "secret-1"},
+		{Name: "dev", Token: "secret-2"},
+	})
+
+	status, body := postMCP(t, ts.URL, "secret-2", initializeRequest)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", status, body)
+	}
+}
+
+// --- A03: ツールスコープ（一覧の絞り込みと呼び出し拒否） ---
+func TestBearerAuth_ToolScope(t *testing.T) {
+	ts := newAuthTestServer(t, []config.AuthToken{
+		{Name: "reader", Token: "read-only", Tools: []string{"slack_get_history"}},
+	})
+
+	_, _, sessionID := postMCPSession(t, ts.URL, "read-only", "", initializeRequest)
+
+	_, body, _ := postMCPSession(t, ts.URL, "read-only", sessionID, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	if !strings.Contains(body, "slack_get_history") {
+		t.Errorf("tools/list = %q, want to contain slack_get_history", body)
+	}
+	if strings.Contains(body, "slack_post_message") {
+		t.Errorf("tools/list = %q, want slack_post_message to be hidden", body)
+	}
+
+	_, body, _ = postMCPSession(t, ts.URL, "read-only", sessionID,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"slack_post_message","arguments":{"message":"hi"}}}`)
+	if !strings.Contains(body, "tool_not_allowed") {
+		t.Errorf("tools/call = %q, want to contain tool_not_allowed", body)
+	}
+}
+
+// --- A04: 不正なトークン設定 ---
+func TestNewAuthTokens_Invalid(t *testing.T) {
+	s := NewServerWithClient(&config.Config{}, &slackclient.MockClient{})
+
+	tests := []struct {
+		name    string
+		tokens  []config.AuthToken
+		wantErr string
+	}{
+		{"empty token", []config.AuthToken{{Name: "ci", Token: ""}}, "is empty"},
+		{"unknown tool", []config.AuthToken{{Name: "ci", Token: "x", Tools: []string{"slack_nope"}}}, "unknown tool"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newAuthTokens(s, tt.tokens)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
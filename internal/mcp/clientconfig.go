@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/cache"
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	"github.com/kai-kou/slack-fast-mcp/internal/logging"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+)
+
+// ValidateConfig は設定を検証する。config.Validate に加え、Slack クライアント・ログ・プロンプトの設定値
+// （retry・rate_limits・log_level・log_format・prompts の since）を全ワークスペース分検証する。
+func ValidateConfig(cfg *config.Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := validatePrompts(cfg.Prompts); err != nil {
+		return err
+	}
+	for _, name := range workspaceNames(cfg) {
+		wcfg, err := cfg.Profile(name)
+		if err != nil {
+			return err
+		}
+		if err := validateClientConfig(wcfg); err != nil {
+			if appErr, ok := err.(*apperr.AppError); ok && name != "" {
+				return apperr.New(appErr.Code, fmt.Sprintf("プロファイル %s: %s", name, appErr.Message), appErr.Err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// validateClientConfig は1つのワークスペース分の Slack クライアント・ログの設定値を検証する。
+func validateClientConfig(cfg *config.Config) error {
+	if _, err := logging.New(io.Discard, cfg.LogLevel, cfg.LogFormat); err != nil {
+		return apperr.New(apperr.CodeConfigParseError,
+			fmt.Sprintf("log_level / log_format の値が不正です: %s / %s", cfg.LogLevel, cfg.LogFormat), err)
+	}
+	if _, err := retryPolicy(cfg.Retry); err != nil {
+		return apperr.New(apperr.CodeConfigParseError,
+			fmt.Sprintf("retry の設定が不正です: %v", err), err)
+	}
+	for key, limit := range cfg.RateLimits {
+		if !slackclient.IsRateLimitKey(key) || limit.PerMinute < 0 || limit.Burst < 0 {
+			return apperr.New(apperr.CodeConfigParseError,
+				fmt.Sprintf("rate_limits の設定が不正です: %s (キーはメソッド名または tier1〜tier4、値は 0 以上)", key), nil)
+		}
+	}
+	return nil
+}
+
+// NewLogger は log_level・log_format に従って w（stderr）へ出力する Logger を返す。
+// 不正な値の場合は warn / text で出力する（ValidateConfig で検出する）。
+func NewLogger(cfg *config.Config, w io.Writer) *slog.Logger {
+	logger, err := logging.New(w, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		logger, _ = logging.New(w, "warn", logging.FormatText)
+	}
+	return logger
+}
+
+// ClientOptions は1つのワークスペース分の設定を Slack クライアントのオプションに変換する。
+// 不正な値はデフォルト値として扱う（ValidateConfig で検出する）。
+func ClientOptions(cfg *config.Config, logger *slog.Logger) []slackclient.ClientOption {
+	policy, err := retryPolicy(cfg.Retry)
+	if err != nil {
+		policy = slackclient.DefaultRetryPolicy()
+	}
+	limits := make(map[string]slackclient.RateLimit, len(cfg.RateLimits))
+	for key, limit := range cfg.RateLimits {
+		limits[key] = slackclient.RateLimit{PerMinute: limit.PerMinute, Burst: limit.Burst}
+	}
+	return []slackclient.ClientOption{
+		slackclient.WithLogger(logger),
+		slackclient.WithUserToken(cfg.UserToken),
+		slackclient.WithDiskCache(cache.Open(DiskCacheTTL(cfg))),
+		slackclient.WithChannelScanLimit(cfg.ChannelScanMaxPages),
+		slackclient.WithRetryPolicy(policy),
+		slackclient.WithRateLimits(limits),
+		slackclient.WithIdempotencyStore(cache.OpenIdempotency(durationOr(cfg.IdempotencyWindow, cache.DefaultIdempotencyWindow))),
+	}
+}
+
+// DiskCacheTTL はディスクキャッシュの有効期間を返す。0 の場合はディスクキャッシュを使用しない。
+func DiskCacheTTL(cfg *config.Config) time.Duration {
+	return durationOr(cfg.CacheTTL, cache.DefaultTTL)
+}
+
+// durationOr は期間の設定値を解析する。空または不正な値の場合は def を返す（config.Validate で検出する）。
+func durationOr(s string, def time.Duration) time.Duration {
+	d, err := config.ParseDuration(s, def)
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+// retryPolicy は retry の設定をデフォルト値に重ねたリトライ方針を返す。
+func retryPolicy(r *config.RetryConfig) (slackclient.RetryPolicy, error) {
+	policy := slackclient.DefaultRetryPolicy()
+	if r == nil {
+		return policy, nil
+	}
+
+	if r.MaxAttempts < 0 {
+		return policy, fmt.Errorf("max_attempts must be >= 1: %d", r.MaxAttempts)
+	}
+	if r.MaxAttempts > 0 {
+		policy.MaxAttempts = r.MaxAttempts
+	}
+	for _, f := range []struct {
+		name string
+		raw  string
+		dst  *time.Duration
+	}{
+		{"base_backoff", r.BaseBackoff, &policy.BaseBackoff},
+		{"max_backoff", r.MaxBackoff, &policy.MaxBackoff},
+	} {
+		if f.raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(f.raw)
+		if err != nil {
+			return policy, fmt.Errorf("%s: %w", f.name, err)
+		}
+		if d < 0 {
+			return policy, fmt.Errorf("%s: negative duration", f.name)
+		}
+		*f.dst = d
+	}
+	if policy.MaxBackoff < policy.BaseBackoff {
+		return policy, fmt.Errorf("max_backoff (%s) must be >= base_backoff (%s)", policy.MaxBackoff, policy.BaseBackoff)
+	}
+	if r.Jitter != nil {
+		if *r.Jitter < 0 || *r.Jitter > 1 {
+			return policy, fmt.Errorf("jitter must be between 0 and 1: %v", *r.Jitter)
+		}
+		policy.Jitter = *r.Jitter
+	}
+	if r.RetryOn != nil {
+		for _, class := range r.RetryOn {
+			if !slices.Contains(slackclient.RetryClasses, class) {
+				return policy, fmt.Errorf("unknown retry_on class %q (want one of %s)",
+					class, strings.Join(slackclient.RetryClasses, ", "))
+			}
+		}
+		policy.RetryOn = r.RetryOn
+	}
+	return policy, nil
+}
@@ -0,0 +1,206 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// トランスポート種別
+const (
+	TransportStdio = "stdio"
+	TransportHTTP  = "http"
+	TransportSSE   = "sse"
+)
+
+// shutdownTimeout は graceful shutdown 時に処理中リクエストの完了を待つ最大時間。
+const shutdownTimeout = 10 * time.Second
+
+// httpTransport は Streamable HTTP / SSE サーバーの共通インターフェース。
+type httpTransport interface {
+	http.Handler
+	Shutdown(ctx context.Context) error
+}
+
+// HTTPOptions は HTTP 系トランスポートの起動オプション。
+type HTTPOptions struct {
+	// Transport は "http"（Streamable HTTP）または "sse"。
+	Transport string
+	// Addr はリッスンアドレス（例: "127.0.0.1:8080"）。
+	Addr string
+	// Logger は起動・リクエストログの出力先（info レベル）。nil の場合はログを出力しない。
+	Logger *slog.Logger
+	// AuthTokens は受け付ける Bearer トークン。空の場合は認証を行わない。
+	AuthTokens []config.AuthToken
+}
+
+// ListenHTTP は指定されたトランスポートで MCP Server を HTTP 公開する。
+// ctx がキャンセルされると graceful shutdown し、nil を返す。
+func ListenHTTP(ctx context.Context, s *server.MCPServer, opts HTTPOptions) error {
+	srv := &http.Server{
+		Addr:              opts.Addr,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	transport, err := newHTTPTransport(s, opts.Transport, srv)
+	if err != nil {
+		return err
+	}
+	srv.Handler, err = wrapHTTPHandler(s, transport, opts)
+	if err != nil {
+		return err
+	}
+
+	// 先に Listen してポート使用中などの起動エラーを即座に返す
+	ln, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return err
+	}
+	if opts.Logger != nil {
+		opts.Logger.Info("listening", "addr", ln.Addr().String(), "transport", opts.Transport)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// SSE セッションのクローズ → HTTP サーバー停止の順で行う
+	if err := transport.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NewHTTPHandler はトランスポート種別に応じた MCP の http.Handler を返す。
+// 認証・リクエストログは opts に従って適用される（opts.Addr は使用しない）。
+func NewHTTPHandler(s *server.MCPServer, opts HTTPOptions) (http.Handler, error) {
+	transport, err := newHTTPTransport(s, opts.Transport, nil)
+	if err != nil {
+		return nil, err
+	}
+	return wrapHTTPHandler(s, transport, opts)
+}
+
+// wrapHTTPHandler は MCP ハンドラに Bearer 認証とリクエストログを適用する。
+// ログは認証より外側に置き、401 応答も記録されるようにする。
+func wrapHTTPHandler(s *server.MCPServer, h http.Handler, opts HTTPOptions) (http.Handler, error) {
+	if len(opts.AuthTokens) > 0 {
+		tokens, err := newAuthTokens(s, opts.AuthTokens)
+		if err != nil {
+			return nil, err
+		}
+		h = withBearerAuth(h, tokens)
+	} else if opts.Logger != nil {
+		opts.Logger.Warn("no auth_tokens configured; anyone who can reach this address can use the bot")
+	}
+	return withRequestLogging(h, opts.Logger), nil
+}
+
+// newHTTPTransport はトランスポート種別から Streamable HTTP / SSE サーバーを生成する。
+// srv を指定すると Shutdown 時にそのサーバーも停止する。
+func newHTTPTransport(s *server.MCPServer, transport string, srv *http.Server) (httpTransport, error) {
+	switch transport {
+	case TransportHTTP:
+		// エンドポイントは "/mcp"
+		var opts []server.StreamableHTTPOption
+		if srv != nil {
+			opts = append(opts, server.WithStreamableHTTPServer(srv))
+		}
+		return &streamableTransport{h: server.NewStreamableHTTPServer(s, opts...)}, nil
+	case TransportSSE:
+		// エンドポイントは "/sse"（イベントストリーム）と "/message"（リクエスト）
+		var opts []server.SSEOption
+		if srv != nil {
+			opts = append(opts, server.WithHTTPServer(srv))
+		}
+		return server.NewSSEServer(s, opts...), nil
+	default:
+		return nil, fmt.Errorf("unsupported transport %q (must be one of: %s, %s, %s)",
+			transport, TransportStdio, TransportHTTP, TransportSSE)
+	}
+}
+
+// streamableTransport は StreamableHTTPServer をエンドポイントパスでルーティングする。
+// StreamableHTTPServer.ServeHTTP はパスを見ないため、"/mcp" 以外は 404 を返す。
+type streamableTransport struct {
+	h *server.StreamableHTTPServer
+}
+
+func (t *streamableTransport) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/mcp" {
+		http.NotFound(w, r)
+		return
+	}
+	t.h.ServeHTTP(w, r)
+}
+
+func (t *streamableTransport) Shutdown(ctx context.Context) error {
+	return t.h.Shutdown(ctx)
+}
+
+// withRequestLogging はリクエストごとにメソッド・パス・ステータス・所要時間を info レベルで出力する。
+func withRequestLogging(next http.Handler, logger *slog.Logger) http.Handler {
+	if logger == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		logger.Info("http request", "method", r.Method, "path", r.URL.Path, "status", rec.status,
+			"duration", time.Since(start).Round(time.Millisecond), "remote", r.RemoteAddr)
+	})
+}
+
+// statusRecorder はレスポンスステータスを記録する ResponseWriter ラッパー。
+// SSE / ストリーミング応答のため http.Flusher を委譲する。
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.status = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap は http.ResponseController 用に元の ResponseWriter を返す。
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	"github.com/kai-kou/slack-fast-mcp/internal/logging"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+)
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`
+
+// --- H01: Streamable HTTP で initialize が成功する ---
+func TestHTTPHandler_StreamableInitialize(t *testing.T) {
+	s := NewServerWithClient(&config.Config{DefaultChannel: "general"}, &slackclient.MockClient{})
+	var logBuf bytes.Buffer
+	logger, _ := logging.New(&logBuf, "info", logging.FormatText)
+	handler, err := NewHTTPHandler(s, HTTPOptions{Transport: TransportHTTP, Logger: logger})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	resp, err := http.Post(ts.URL+"/mcp", "application/json", strings.NewReader(initializeRequest))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200 (body: %s)", resp.StatusCode, body)
+	}
+	if !strings.Contains(string(body), "slack-fast-mcp") {
+		t.Errorf("body = %q, want to contain server name", body)
+	}
+	if !strings.Contains(logBuf.String(), "method=POST path=/mcp status=200") {
+		t.Errorf("log = %q, want request log line", logBuf.String())
+	}
+}
+
+// --- H02: エンドポイント以外のパスは 404 ---
+func TestHTTPHandler_StreamableUnknownPath(t *testing.T) {
+	s := NewServerWithClient(&config.Config{}, &slackclient.MockClient{})
+	handler, err := NewHTTPHandler(s, HTTPOptions{Transport: TransportHTTP})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/other", strings.NewReader(initializeRequest)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+}
+
+// --- H03: 未対応トランスポート ---
+func TestHTTPHandler_UnsupportedTransport(t *testing.T) {
+	s := NewServerWithClient(&config.Config{}, &slackclient.MockClient{})
+	_, err := NewHTTPHandler(s, HTTPOptions{Transport: "websocket"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "unsupported transport") {
+		t.Errorf("error = %q, want to contain %q", err.Error(), "unsupported transport")
+	}
+}
+
+// --- H04: context キャンセルで graceful shutdown ---
+func TestListenHTTP_GracefulShutdown(t *testing.T) {
+	for _, transport := range []string{TransportHTTP, TransportSSE} {
+		t.Run(transport, func(t *testing.T) {
+			s := NewServerWithClient(&config.Config{}, &slackclient.MockClient{})
+			ctx, cancel := context.WithCancel(context.Background())
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- ListenHTTP(ctx, s, HTTPOptions{Transport: transport, Addr: "127.0.0.1:0"})
+			}()
+
+			cancel()
+			select {
+			case err := <-errCh:
+				if err != nil {
+					t.Errorf("ListenHTTP returned error: %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("ListenHTTP did not return after context cancel")
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// logToolCall はツール呼び出しごとにツール名・所要時間・リトライ回数・結果を記録するミドルウェア。
+// 成功は info、エラー結果は warn、引数は debug レベルで出力する。
+// debug レベルの場合は、リトライが発生したツール結果（JSON）に "retries" を追加する。
+func logToolCall(logger *slog.Logger) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name := request.Params.Name
+			logger.Debug("tool call started", "tool", name, "arguments", request.GetArguments())
+
+			ctx, stats := slackclient.WithRetryStats(ctx)
+			start := time.Now()
+			result, err := next(ctx, request)
+			elapsed := time.Since(start)
+			retries := stats.Total()
+
+			switch {
+			case err != nil:
+				logger.Error("tool call failed", "tool", name, "duration", elapsed, "retries", retries, "error", err)
+			case result != nil && result.IsError:
+				logger.Warn("tool call returned error", "tool", name, "duration", elapsed, "retries", retries, "error", firstLine(resultText(result)))
+			default:
+				logger.Info("tool call", "tool", name, "duration", elapsed, "retries", retries)
+				if retries > 0 && logger.Enabled(ctx, slog.LevelDebug) {
+					addRetries(result, stats)
+				}
+			}
+			return result, err
+		}
+	}
+}
+
+// addRetries はツール結果の JSON オブジェクトに "retries"（合計回数と API メソッドごとの回数）を追加する。
+// JSON オブジェクト以外の結果は変更しない。
+func addRetries(result *mcp.CallToolResult, stats *slackclient.RetryStats) {
+	if result == nil || len(result.Content) != 1 {
+		return
+	}
+	tc, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return
+	}
+	text := strings.TrimSpace(tc.Text)
+	if !strings.HasPrefix(text, "{") || !strings.HasSuffix(text, "}") || !json.Valid([]byte(text)) {
+		return
+	}
+	retries, err := json.Marshal(map[string]any{
+		"total":     stats.Total(),
+		"by_method": stats.ByMethod(),
+	})
+	if err != nil {
+		return
+	}
+
+	// フィールドの順序を保つため、末尾の } の直前に追加する
+	body := strings.TrimSpace(text[1 : len(text)-1])
+	sep := ","
+	if body == "" {
+		sep = ""
+	}
+	tc.Text = "{" + body + sep + `"retries":` + string(retries) + "}"
+	result.Content[0] = tc
+}
+
+// resultText はツール結果のテキストを連結して返す。
+func resultText(result *mcp.CallToolResult) string {
+	var b strings.Builder
+	for _, c := range result.Content {
+		if tc, ok := c.(mcp.TextContent); ok {
+			b.WriteString(tc.Text)
+		}
+	}
+	return b.String()
+}
+
+// firstLine は文字列の1行目を返す（エラーメッセージのヒント行を省く）。
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
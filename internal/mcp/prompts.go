@@ -0,0 +1,218 @@
+package mcp
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultPromptSince は since 引数を省略した場合の取得開始時刻。
+	defaultPromptSince = "24h"
+	// promptHistoryMaxPages はプロンプトに埋め込む履歴の最大ページ数（1ページ100件）。
+	promptHistoryMaxPages = 3
+)
+
+// builtinPrompts は組み込みのプロンプト。設定の prompts で同じ名前を指定すると置き換えられる。
+var builtinPrompts = []config.PromptConfig{
+	{
+		Name:        "summarize_channel",
+		Description: "Summarize a channel's conversation since a given time: topics, decisions, open questions and action items.",
+		Instructions: "Summarize the Slack conversation in {channel} since {since}. Group it by topic, then list decisions, " +
+			"open questions and action items (with owners) separately. Refer to specific messages by their ts.",
+	},
+	{
+		Name:        "draft_standup",
+		Description: "Draft a standup post (Yesterday / Today / Blockers) from a channel's recent activity.",
+		Instructions: "Draft a standup update for {persona} based on the activity in {channel} since {since}, " +
+			"with three sections: Yesterday, Today and Blockers. Keep each bullet short. " +
+			"Show the draft to the user for review before posting it with slack_post_message.",
+	},
+}
+
+// registerPrompts は組み込みのプロンプトと設定の prompts を登録する。
+// プロンプトはチャンネルの履歴を取得して埋め込むため、slack_get_history が登録されていない場合は登録しない。
+func registerPrompts(s *server.MCPServer, ws *workspaces, prompts []config.PromptConfig) {
+	if s.GetTool("slack_get_history") == nil {
+		return
+	}
+	for _, p := range append(slices.Clone(builtinPrompts), prompts...) {
+		s.AddPrompt(ws.prompt(newPrompt(p)), ws.promptHandler(func(client slackclient.SlackClient, cfg *config.Config) server.PromptHandlerFunc {
+			return historyPromptHandler(p, client, cfg)
+		}))
+	}
+}
+
+// validatePrompts は設定の prompts の since を検証する（名前・指示文は config.Validate で検証する）。
+func validatePrompts(prompts []config.PromptConfig) error {
+	for _, p := range prompts {
+		if p.Since == "" {
+			continue
+		}
+		if _, err := slackclient.ParseSince(p.Since, time.Now()); err != nil {
+			return apperr.New(apperr.CodeConfigParseError,
+				fmt.Sprintf("prompts %s: since の形式が不正です: %s", p.Name, p.Since), err)
+		}
+	}
+	return nil
+}
+
+// newPrompt はプロンプトの定義（引数: channel, since, until, persona）を作成する。
+func newPrompt(p config.PromptConfig) mcp.Prompt {
+	since := cmp.Or(p.Since, defaultPromptSince)
+	channelDesc := "Channel name or ID to read. If omitted, uses the default channel from config."
+	if p.Channel != "" {
+		channelDesc = fmt.Sprintf("Channel name or ID to read. If omitted, uses '%s'.", p.Channel)
+	}
+	return mcp.NewPrompt(p.Name,
+		mcp.WithPromptDescription(p.Description),
+		mcp.WithArgument("channel", mcp.ArgumentDescription(channelDesc)),
+		mcp.WithArgument("since", mcp.ArgumentDescription(fmt.Sprintf(
+			"Start of the time range: a duration back from now (e.g. '24h', '7d'), a date (e.g. '2026-01-01'), or RFC3339. Default: '%s'.", since))),
+		mcp.WithArgument("until", mcp.ArgumentDescription(
+			"End of the time range, in the same formats as since (e.g. '1d', '2026-01-08'). Default: now.")),
+		mcp.WithArgument("persona", mcp.ArgumentDescription(
+			"Who the result is for or written as (e.g. 'engineering manager', '@alice').")),
+	)
+}
+
+// prompt はプロファイルが設定されている場合にプロンプトへ workspace 引数を追加する。
+func (w *workspaces) prompt(p mcp.Prompt) mcp.Prompt {
+	if len(w.names) == 0 {
+		return p
+	}
+	desc := fmt.Sprintf("Slack workspace (config profile) to read: %s. If omitted, uses the default workspace.", quoteNames(w.names))
+	mcp.WithArgument("workspace", mcp.ArgumentDescription(desc))(&p)
+	return p
+}
+
+// promptHandler はワークスペースごとにプロンプトのハンドラを作成し、workspace 引数で振り分けるハンドラを返す。
+// 履歴を取得するため、slack_get_history を許可されていないトークンからの取得は拒否する。
+func (w *workspaces) promptHandler(newHandler func(slackclient.SlackClient, *config.Config) server.PromptHandlerFunc) server.PromptHandlerFunc {
+	handlers := make(map[string]server.PromptHandlerFunc, len(w.clients))
+	for name, client := range w.clients {
+		handlers[name] = newHandler(client, w.configs[name])
+	}
+
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		if !isToolAllowed(ctx, "slack_get_history") {
+			return nil, protocolError(apperr.New(apperr.CodeToolNotAllowed,
+				"このトークンでは取得できないプロンプトです: "+request.Params.Name, nil))
+		}
+
+		name := request.Params.Arguments["workspace"]
+		if name == "" {
+			name = w.defaultName
+		}
+		h, ok := handlers[name]
+		if !ok {
+			return nil, protocolError(apperr.New(apperr.CodeProfileNotFound,
+				fmt.Sprintf("ワークスペースが見つかりません: %s（設定済み: %s）", name, strings.Join(w.names, ", ")), nil))
+		}
+		return h(ctx, request)
+	}
+}
+
+// historyPromptHandler はチャンネルの履歴を取得し、指示文に続けて埋め込んだプロンプトを返すハンドラ。
+func historyPromptHandler(p config.PromptConfig, client slackclient.SlackClient, cfg *config.Config) server.PromptHandlerFunc {
+	return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := request.Params.Arguments
+
+		channel, err := cfg.ResolveChannel(cmp.Or(args["channel"], p.Channel))
+		if err != nil {
+			return nil, protocolError(err)
+		}
+
+		now := time.Now()
+		since, err := slackclient.ParseSince(cmp.Or(args["since"], p.Since, defaultPromptSince), now)
+		if err != nil {
+			return nil, protocolError(err)
+		}
+		var until time.Time
+		if args["until"] != "" {
+			if until, err = slackclient.ParseSince(args["until"], now); err != nil {
+				return nil, protocolError(err)
+			}
+			if !until.After(since) {
+				return nil, protocolError(apperr.New(apperr.CodeInvalidSince,
+					fmt.Sprintf("until は since より後の時刻を指定してください: %s", args["until"]), nil))
+			}
+		}
+
+		history, truncated, err := fetchPromptHistory(ctx, client, channel, since, until)
+		if err != nil {
+			return nil, protocolError(err)
+		}
+
+		heading := channelHeading(history.ChannelName, history.Channel)
+		persona := args["persona"]
+		text := strings.NewReplacer(
+			"{channel}", heading,
+			"{since}", since.UTC().Format(displayTimeLayout),
+			"{persona}", cmp.Or(persona, "the user"),
+		).Replace(p.Instructions)
+
+		var b strings.Builder
+		b.WriteString(strings.TrimSpace(text))
+		b.WriteString("\n\n")
+		if persona != "" && !strings.Contains(p.Instructions, "{persona}") {
+			fmt.Fprintf(&b, "Persona: %s\n\n", persona)
+		}
+		timeRange := "since " + since.UTC().Format(displayTimeLayout)
+		if !until.IsZero() {
+			timeRange += " until " + until.UTC().Format(displayTimeLayout)
+		}
+		fmt.Fprintf(&b, "---\n\n## Messages in %s %s (%d)\n\n", heading, timeRange, len(history.Messages))
+		if truncated {
+			fmt.Fprintf(&b, "_Only the latest %d messages are included._\n\n", len(history.Messages))
+		}
+		writeMessages(&b, history.Messages, history.Channel, workspaceQuery(cfg), true)
+
+		return mcp.NewGetPromptResult(p.Description, []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(b.String())),
+		}), nil
+	}
+}
+
+// fetchPromptHistory は since 以降（until がゼロ値でなければ until まで）の履歴を最大 promptHistoryMaxPages ページ取得し、
+// 古い順に並べて返す。取得しきれなかったメッセージがある場合は truncated が true になる。
+func fetchPromptHistory(ctx context.Context, client slackclient.SlackClient, channel string, since, until time.Time) (*slackclient.HistoryResult, bool, error) {
+	opts := slackclient.HistoryOptions{Limit: 100, Oldest: strconv.FormatInt(since.Unix(), 10)}
+	if !until.IsZero() {
+		opts.Latest = strconv.FormatInt(until.Unix(), 10)
+	}
+	var all *slackclient.HistoryResult
+	for page := 0; page < promptHistoryMaxPages; page++ {
+		result, err := client.GetHistory(ctx, channel, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		if all == nil {
+			first := *result
+			first.Messages = slices.Clone(result.Messages)
+			all = &first
+		} else {
+			all.Messages = append(all.Messages, result.Messages...)
+		}
+		if !result.HasMore || result.NextCursor == "" {
+			all.HasMore = false
+			break
+		}
+		all.HasMore = true
+		opts.Cursor = result.NextCursor
+	}
+	// 履歴は新しい順で返るため、読みやすいよう古い順に並べ替える
+	slices.Reverse(all.Messages)
+	all.Count = len(all.Messages)
+	return all, all.HasMore, nil
+}
@@ -0,0 +1,159 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// getPrompt は prompts/get を送信し、最初のメッセージのテキストとエラーメッセージを返す。
+func getPrompt(t *testing.T, ctx context.Context, s *server.MCPServer, name string, args map[string]string) (string, string) {
+	t.Helper()
+	req, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "prompts/get",
+		"params":  map[string]any{"name": name, "arguments": args},
+	})
+	switch resp := s.HandleMessage(ctx, req).(type) {
+	case mcp.JSONRPCResponse:
+		result := resp.Result.(mcp.GetPromptResult)
+		return result.Messages[0].Content.(mcp.TextContent).Text, ""
+	case mcp.JSONRPCError:
+		return "", resp.Error.Message
+	default:
+		t.Fatalf("unexpected response %#v", resp)
+		return "", ""
+	}
+}
+
+// --- M55: 組み込みのプロンプトは since 以降の履歴を取得して埋め込む ---
+func TestServer_Prompts(t *testing.T) {
+	var gotChannel string
+	var gotOpts []slackclient.HistoryOptions
+	mock := &slackclient.MockClient{
+		GetHistoryFunc: func(ctx context.Context, channel string, opts slackclient.HistoryOptions) (*slackclient.HistoryResult, error) {
+			gotChannel = channel
+			gotOpts = append(gotOpts, opts)
+			if opts.Cursor == "" {
+				return &slackclient.HistoryResult{
+					Channel: "C01234ABCDE", ChannelName: "incidents",
+					Messages:   []slackclient.HistoryMessage{{UserName: "bob", Text: "resolved", TS: "1700000100.000200"}},
+					HasMore:    true,
+					NextCursor: "page2",
+				}, nil
+			}
+			return &slackclient.HistoryResult{
+				Channel: "C01234ABCDE", ChannelName: "incidents",
+				Messages: []slackclient.HistoryMessage{{UserName: "alice", Text: "db is down", TS: "1700000000.000100"}},
+			}, nil
+		},
+	}
+	s := NewServerWithClient(&config.Config{Token: "xoxb-test", DefaultChannel: "general"}, mock)
+	ctx := context.Background()
+
+	start := time.Now()
+	text, errMsg := getPrompt(t, ctx, s, "summarize_channel", map[string]string{"channel": "incidents", "since": "2h", "persona": "on-call engineer"})
+	if errMsg != "" {
+		t.Fatalf("prompts/get error = %q", errMsg)
+	}
+	if gotChannel != "incidents" || len(gotOpts) != 2 || gotOpts[1].Cursor != "page2" {
+		t.Errorf("GetHistory channel = %q, opts = %+v; want 2 pages of incidents", gotChannel, gotOpts)
+	}
+	if oldest, _ := strconv.ParseInt(gotOpts[0].Oldest, 10, 64); oldest < start.Add(-2*time.Hour-time.Second).Unix() || oldest > start.Add(-2*time.Hour).Unix()+1 {
+		t.Errorf("oldest = %s, want 2h ago", gotOpts[0].Oldest)
+	}
+	for _, want := range []string{
+		"Summarize the Slack conversation in #incidents (C01234ABCDE) since ",
+		"Persona: on-call engineer",
+		"## Messages in #incidents (C01234ABCDE)",
+		"**@alice**",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("prompt missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "db is down") > strings.Index(text, "resolved") {
+		t.Errorf("messages should be oldest first:\n%s", text)
+	}
+
+	// channel 省略時は default_channel、persona は指示文に埋め込む
+	gotOpts = nil
+	text, _ = getPrompt(t, ctx, s, "draft_standup", map[string]string{"persona": "@alice"})
+	if gotChannel != "general" || !strings.Contains(text, "Draft a standup update for @alice") || strings.Contains(text, "Persona:") {
+		t.Errorf("draft_standup channel = %q, text = %q", gotChannel, text)
+	}
+
+	// until は Latest として渡し、見出しに範囲を含める
+	gotOpts = nil
+	text, _ = getPrompt(t, ctx, s, "summarize_channel", map[string]string{"since": "7d", "until": "1d"})
+	if latest, _ := strconv.ParseInt(gotOpts[0].Latest, 10, 64); latest < start.Add(-24*time.Hour-time.Second).Unix() || latest > start.Add(-24*time.Hour).Unix()+1 {
+		t.Errorf("latest = %q, want 1d ago", gotOpts[0].Latest)
+	}
+	if !strings.Contains(text, " until ") {
+		t.Errorf("prompt should show the until time:\n%s", text)
+	}
+
+	for _, args := range []map[string]string{
+		{"since": "last week"},
+		{"since": "1d", "until": "7d"},
+		{"until": "tomorrow"},
+	} {
+		if _, errMsg := getPrompt(t, ctx, s, "summarize_channel", args); !strings.Contains(errMsg, "invalid_since") {
+			t.Errorf("%v error = %q, want invalid_since", args, errMsg)
+		}
+	}
+	restricted := withAllowedTools(ctx, map[string]bool{"slack_post_message": true})
+	if _, errMsg := getPrompt(t, restricted, s, "summarize_channel", nil); !strings.Contains(errMsg, "tool_not_allowed") {
+		t.Errorf("restricted error = %q, want tool_not_allowed", errMsg)
+	}
+}
+
+// --- M56: 設定の prompts を登録し、組み込みと同じ名前は置き換える ---
+func TestServer_ConfigPrompts(t *testing.T) {
+	var gotChannel string
+	mock := &slackclient.MockClient{
+		GetHistoryFunc: func(ctx context.Context, channel string, opts slackclient.HistoryOptions) (*slackclient.HistoryResult, error) {
+			gotChannel = channel
+			return &slackclient.HistoryResult{Channel: "C01234ABCDE", ChannelName: channel}, nil
+		},
+	}
+	cfg := &config.Config{
+		Token: "xoxb-test",
+		Prompts: []config.PromptConfig{
+			{Name: "incident_review", Description: "Review incidents", Instructions: "Write a postmortem for {persona} covering {channel}.", Channel: "incidents", Since: "7d"},
+			{Name: "summarize_channel", Instructions: "TL;DR of {channel}."},
+		},
+	}
+	s := NewServerWithClient(cfg, mock)
+	ctx := context.Background()
+
+	req, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "prompts/list"})
+	resp := s.HandleMessage(ctx, req).(mcp.JSONRPCResponse)
+	var names []string
+	for _, p := range resp.Result.(mcp.ListPromptsResult).Prompts {
+		names = append(names, p.Name)
+	}
+	if strings.Join(names, ",") != "draft_standup,incident_review,summarize_channel" {
+		t.Errorf("prompts = %v", names)
+	}
+
+	text, errMsg := getPrompt(t, ctx, s, "incident_review", nil)
+	if errMsg != "" || gotChannel != "incidents" || !strings.HasPrefix(text, "Write a postmortem for the user covering #incidents (C01234ABCDE).") ||
+		!strings.Contains(text, "(no messages)") {
+		t.Errorf("incident_review channel = %q, text = %q (error %q)", gotChannel, text, errMsg)
+	}
+
+	text, _ = getPrompt(t, ctx, s, "summarize_channel", map[string]string{"channel": "random"})
+	if !strings.HasPrefix(text, "TL;DR of #random") {
+		t.Errorf("overridden summarize_channel = %q", text)
+	}
+}
@@ -0,0 +1,271 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// channelsURI はBotが参加しているチャンネル一覧のリソースURI。
+	channelsURI = "slack://channels"
+
+	// resourceHistoryLimit は履歴リソースに含めるメッセージ数。
+	resourceHistoryLimit = 50
+	// resourceThreadLimit はスレッドリソースに含めるメッセージ数。
+	resourceThreadLimit = 200
+	// resourceChannelsLimit はチャンネル一覧リソースに含めるチャンネル数。
+	resourceChannelsLimit = 1000
+
+	// displayTimeLayout はメッセージの日時の表示形式。
+	displayTimeLayout = "2006-01-02 15:04 UTC"
+
+	// resourceMIMEType はリソースの形式（エージェントがそのまま読めるよう Markdown で出力する）。
+	resourceMIMEType = "text/markdown"
+)
+
+// resourceHandlerFactory はワークスペース1つ分のリソースハンドラを作成する関数。
+type resourceHandlerFactory func(slackclient.SlackClient, *config.Config) server.ResourceTemplateHandlerFunc
+
+// registerResources はチャンネル一覧・履歴・スレッドのリソースを登録する。
+// チャンネルは個別のリソースとして登録せず、slack://channels の一覧とテンプレートで参照する
+// （resources/list はトークンによらず同じ内容を返すため、許可されていないトークンにチャンネル名を見せない）。
+// 同じ内容を返すツールが登録されていない場合（tool_registration: strict で非表示など）は登録しない。
+// HTTP トランスポートでは、対応するツールを許可されたトークンのみ読み取れる。
+func registerResources(s *server.MCPServer, ws *workspaces) {
+	query := ""
+	if len(ws.names) > 0 {
+		query = "{?workspace}"
+	}
+
+	if s.GetTool("slack_list_channels") != nil {
+		// workspace 未指定（デフォルトのワークスペース）と各プロファイルのチャンネル一覧
+		for _, name := range append([]string{""}, ws.names...) {
+			uri := channelsURI
+			title := "Slack channels the bot belongs to"
+			if name != "" {
+				uri += "?workspace=" + url.QueryEscape(name)
+				title += " (" + name + ")"
+			}
+			s.AddResource(mcp.NewResource(uri, title,
+				mcp.WithResourceDescription("Channels the bot is a member of, with the resource URI of each channel's history."),
+				mcp.WithMIMEType(resourceMIMEType),
+			), server.ResourceHandlerFunc(ws.resourceHandler("slack_list_channels", name, channelsResource)))
+		}
+	}
+
+	if s.GetTool("slack_get_history") != nil {
+		s.AddResourceTemplate(mcp.NewResourceTemplate("slack://channel/{channel}/history"+query, "Slack channel history",
+			mcp.WithTemplateDescription(fmt.Sprintf("The latest %d messages of a channel as readable text (oldest first). "+
+				"channel is a channel name (e.g. 'general') or ID (e.g. 'C01234ABCDE').", resourceHistoryLimit)),
+			mcp.WithTemplateMIMEType(resourceMIMEType),
+		), ws.resourceHandler("slack_get_history", "", historyResource))
+	}
+
+	if s.GetTool("slack_get_thread") != nil {
+		s.AddResourceTemplate(mcp.NewResourceTemplate("slack://channel/{channel}/thread/{ts}"+query, "Slack thread",
+			mcp.WithTemplateDescription("A thread (parent message and replies) as readable text. "+
+				"ts is the parent message timestamp (e.g. '1234567890.123456')."),
+			mcp.WithTemplateMIMEType(resourceMIMEType),
+		), ws.resourceHandler("slack_get_thread", "", threadResource))
+	}
+}
+
+// resourceHandler はワークスペースごとにリソースハンドラを作成し、workspace（URI のクエリ）で振り分けるハンドラを返す。
+// fixed が空でない場合は常にそのワークスペースを使う。tool を許可されていないトークンからの読み取りは拒否する。
+func (w *workspaces) resourceHandler(tool, fixed string, newHandler resourceHandlerFactory) server.ResourceTemplateHandlerFunc {
+	handlers := make(map[string]server.ResourceTemplateHandlerFunc, len(w.clients))
+	for name, client := range w.clients {
+		handlers[name] = newHandler(client, w.configs[name])
+	}
+
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if !isToolAllowed(ctx, tool) {
+			return nil, protocolError(apperr.New(apperr.CodeToolNotAllowed,
+				"このトークンでは読み取れないリソースです: "+request.Params.URI, nil))
+		}
+
+		name := fixed
+		if name == "" {
+			name = resourceArg(request, "workspace")
+		}
+		if name == "" {
+			name = w.defaultName
+		}
+		h, ok := handlers[name]
+		if !ok {
+			return nil, protocolError(apperr.New(apperr.CodeProfileNotFound,
+				fmt.Sprintf("ワークスペースが見つかりません: %s（設定済み: %s）", name, strings.Join(w.names, ", ")), nil))
+		}
+		return h(ctx, request)
+	}
+}
+
+// channelsResource はBotが参加しているチャンネル一覧を返すリソースハンドラ。
+func channelsResource(client slackclient.SlackClient, cfg *config.Config) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		result, err := client.ListChannels(ctx, slackclient.ListChannelsOptions{
+			MemberOnly: true,
+			Limit:      resourceChannelsLimit,
+		})
+		if err != nil {
+			return nil, protocolError(err)
+		}
+
+		query := workspaceQuery(cfg)
+		var b strings.Builder
+		fmt.Fprintf(&b, "# Channels the bot belongs to (%d)\n\n", result.Count)
+		for _, ch := range result.Channels {
+			visibility := ""
+			if ch.IsPrivate {
+				visibility = " 🔒"
+			}
+			fmt.Fprintf(&b, "- #%s%s (%s) — %d members — slack://channel/%s/history%s\n",
+				ch.Name, visibility, ch.ID, ch.NumMembers, ch.ID, query)
+			if ch.Topic != "" {
+				fmt.Fprintf(&b, "  Topic: %s\n", oneLine(ch.Topic))
+			}
+		}
+		if len(result.Channels) == 0 {
+			b.WriteString("The bot is not a member of any channel. Invite it with /invite @bot-name.\n")
+		}
+		if result.HasMore {
+			fmt.Fprintf(&b, "\n_More channels may exist. Use the slack_list_channels tool with member_only true and cursor %q to continue._\n", result.NextCursor)
+		}
+		return textResource(request, b.String()), nil
+	}
+}
+
+// historyResource はチャンネルの最新メッセージを返すリソースハンドラ。
+func historyResource(client slackclient.SlackClient, cfg *config.Config) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		result, err := client.GetHistory(ctx, resourceArg(request, "channel"), slackclient.HistoryOptions{Limit: resourceHistoryLimit})
+		if err != nil {
+			return nil, protocolError(err)
+		}
+
+		// 履歴は新しい順で返るため、読みやすいよう古い順に並べ替える
+		messages := slices.Clone(result.Messages)
+		slices.Reverse(messages)
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s — latest %d messages\n\n", channelHeading(result.ChannelName, result.Channel), result.Count)
+		if result.HasMore {
+			fmt.Fprintf(&b, "_Older messages are not included. Use the slack_get_history tool with cursor %q to read more._\n\n", result.NextCursor)
+		}
+		writeMessages(&b, messages, result.Channel, workspaceQuery(cfg), true)
+		return textResource(request, b.String()), nil
+	}
+}
+
+// threadResource はスレッドの元メッセージと返信を返すリソースハンドラ。
+func threadResource(client slackclient.SlackClient, _ *config.Config) server.ResourceTemplateHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		result, err := client.GetThreadReplies(ctx, resourceArg(request, "channel"), resourceArg(request, "ts"),
+			slackclient.ThreadOptions{Limit: resourceThreadLimit})
+		if err != nil {
+			return nil, protocolError(err)
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# Thread %s in %s (%d messages)\n\n", result.ThreadTS, channelHeading(result.ChannelName, result.Channel), result.Count)
+		writeMessages(&b, result.Messages, result.Channel, "", false)
+		if result.HasMore {
+			fmt.Fprintf(&b, "_More replies are not included. Use the slack_get_thread tool with cursor %q to read more._\n", result.NextCursor)
+		}
+		return textResource(request, b.String()), nil
+	}
+}
+
+// writeMessages はメッセージを「投稿者・日時・本文」の読みやすい形式で書き出す。
+// linkThreads の場合、返信のあるスレッド元メッセージにスレッドのリソースURI（query はワークスペースのクエリ）を添える。
+func writeMessages(b *strings.Builder, messages []slackclient.HistoryMessage, channelID, query string, linkThreads bool) {
+	if len(messages) == 0 {
+		b.WriteString("(no messages)\n")
+		return
+	}
+	for _, msg := range messages {
+		user := msg.UserName
+		if user == "" {
+			user = msg.User
+		}
+		fmt.Fprintf(b, "**@%s** · %s · ts %s\n", user, formatTS(msg.TS), msg.TS)
+		b.WriteString(strings.TrimRight(msg.Text, "\n"))
+		b.WriteString("\n")
+		if linkThreads && msg.ReplyCount > 0 && msg.ThreadTS == msg.TS {
+			fmt.Fprintf(b, "💬 %d replies — slack://channel/%s/thread/%s%s\n", msg.ReplyCount, channelID, msg.TS, query)
+		}
+		b.WriteString("\n")
+	}
+}
+
+// formatTS は Slack のタイムスタンプ（例: "1234567890.123456"）を UTC の日時に変換する。解析できない場合はそのまま返す。
+func formatTS(ts string) string {
+	sec, _, _ := strings.Cut(ts, ".")
+	unix, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return ts
+	}
+	return time.Unix(unix, 0).UTC().Format(displayTimeLayout)
+}
+
+// channelHeading はリソースの見出しに使うチャンネル表記を返す。
+func channelHeading(name, id string) string {
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("#%s (%s)", name, id)
+}
+
+// workspaceQuery はリソースURIに付与するワークスペースのクエリ（プロファイルでない場合は空）を返す。
+func workspaceQuery(cfg *config.Config) string {
+	if cfg.ProfileName == "" {
+		return ""
+	}
+	return "?workspace=" + url.QueryEscape(cfg.ProfileName)
+}
+
+// oneLine は改行を空白に置き換える。
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// resourceArg は URI テンプレートの変数（またはクエリ）の値を返す。
+func resourceArg(request mcp.ReadResourceRequest, name string) string {
+	switch v := request.Params.Arguments[name].(type) {
+	case string:
+		return v
+	case []string:
+		if len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+// textResource はリソースの内容（Markdown テキスト）を返す。
+func textResource(request mcp.ReadResourceRequest, text string) []mcp.ResourceContents {
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      request.Params.URI,
+		MIMEType: resourceMIMEType,
+		Text:     text,
+	}}
+}
+
+// protocolError はエラーをリソース・プロンプト取得のエラーに変換する（AppError の場合はヒントを含める）。
+func protocolError(err error) error {
+	if appErr, ok := err.(*apperr.AppError); ok {
+		return fmt.Errorf("%s", appErr.FormatForMCP())
+	}
+	return err
+}
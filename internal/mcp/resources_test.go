@@ -0,0 +1,206 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	"github.com/kai-kou/slack-fast-mcp/internal/logging"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// readResource は resources/read を送信し、テキストとエラーメッセージを返す。
+func readResource(t *testing.T, ctx context.Context, s *server.MCPServer, uri string) (string, string) {
+	t.Helper()
+	req, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "resources/read",
+		"params":  map[string]any{"uri": uri},
+	})
+	switch resp := s.HandleMessage(ctx, req).(type) {
+	case mcp.JSONRPCResponse:
+		result := resp.Result.(mcp.ReadResourceResult)
+		return result.Contents[0].(mcp.TextResourceContents).Text, ""
+	case mcp.JSONRPCError:
+		return "", resp.Error.Message
+	default:
+		t.Fatalf("unexpected response %#v", resp)
+		return "", ""
+	}
+}
+
+// listResources は resources/list と resources/templates/list を送信し、URI（テンプレート）の一覧を返す。
+func listResources(t *testing.T, s *server.MCPServer) (uris, templates []string) {
+	t.Helper()
+	for _, method := range []string{"resources/list", "resources/templates/list"} {
+		req, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method})
+		resp, ok := s.HandleMessage(context.Background(), req).(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("%s: response is not JSONRPCResponse", method)
+		}
+		switch result := resp.Result.(type) {
+		case mcp.ListResourcesResult:
+			for _, r := range result.Resources {
+				uris = append(uris, r.URI)
+			}
+		case mcp.ListResourceTemplatesResult:
+			for _, r := range result.ResourceTemplates {
+				templates = append(templates, r.URITemplate.Raw())
+			}
+		}
+	}
+	return uris, templates
+}
+
+// --- M52: チャンネル一覧・履歴・スレッドのリソースを読みやすいテキストで返す ---
+func TestServer_Resources(t *testing.T) {
+	mock := &slackclient.MockClient{
+		ListChannelsFunc: func(ctx context.Context, opts slackclient.ListChannelsOptions) (*slackclient.ListChannelsResult, error) {
+			if !opts.MemberOnly {
+				t.Error("channels resource should list member channels only")
+			}
+			return &slackclient.ListChannelsResult{
+				Channels:   []slackclient.ChannelInfo{{ID: "C01234ABCDE", Name: "general", NumMembers: 42, Topic: "Company\nwide"}},
+				HasMore:    true,
+				NextCursor: "Y2hhbm5lbHM=",
+				Count:      1,
+			}, nil
+		},
+		GetHistoryFunc: func(ctx context.Context, channel string, opts slackclient.HistoryOptions) (*slackclient.HistoryResult, error) {
+			if channel != "#general" {
+				t.Errorf("channel = %q, want #general", channel)
+			}
+			return &slackclient.HistoryResult{
+				Channel:     "C01234ABCDE",
+				ChannelName: "general",
+				Messages: []slackclient.HistoryMessage{
+					{User: "U2", UserName: "bob", Text: "newer", TS: "1700000100.000200"},
+					{User: "U1", UserName: "alice", Text: "older", TS: "1700000000.000100", ThreadTS: "1700000000.000100", ReplyCount: 2},
+				},
+				HasMore:    true,
+				NextCursor: "bmV4dA==",
+				Count:      2,
+			}, nil
+		},
+		GetThreadRepliesFunc: func(ctx context.Context, channel, threadTS string, opts slackclient.ThreadOptions) (*slackclient.ThreadResult, error) {
+			return &slackclient.ThreadResult{
+				Channel:  channel,
+				ThreadTS: threadTS,
+				Messages: []slackclient.HistoryMessage{
+					{User: "U1", Text: "parent", TS: threadTS, ThreadTS: threadTS, ReplyCount: 1},
+					{User: "U2", Text: "reply", TS: "1700000050.000300", ThreadTS: threadTS},
+				},
+				Count: 2,
+			}, nil
+		},
+	}
+	s := NewServerWithClient(&config.Config{Token: "xoxb-test"}, mock)
+	ctx := context.Background()
+
+	uris, templates := listResources(t, s)
+	if strings.Join(uris, ",") != channelsURI {
+		t.Errorf("resources = %v, want %s only", uris, channelsURI)
+	}
+	if strings.Join(templates, ",") != "slack://channel/{channel}/history,slack://channel/{channel}/thread/{ts}" {
+		t.Errorf("resource templates = %v", templates)
+	}
+
+	text, errMsg := readResource(t, ctx, s, channelsURI)
+	if errMsg != "" || !strings.Contains(text, "- #general (C01234ABCDE) — 42 members — slack://channel/C01234ABCDE/history") ||
+		!strings.Contains(text, "Topic: Company wide") || !strings.Contains(text, `member_only true and cursor "Y2hhbm5lbHM="`) {
+		t.Errorf("channels = %q (error %q)", text, errMsg)
+	}
+
+	text, errMsg = readResource(t, ctx, s, "slack://channel/%23general/history")
+	if errMsg != "" {
+		t.Fatalf("history error = %q", errMsg)
+	}
+	for _, want := range []string{
+		"# #general (C01234ABCDE)",
+		"**@alice** · 2023-11-14 22:13 UTC · ts 1700000000.000100\nolder",
+		"💬 2 replies — slack://channel/C01234ABCDE/thread/1700000000.000100",
+		`cursor "bmV4dA=="`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("history missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "older") > strings.Index(text, "newer") {
+		t.Errorf("history should be oldest first:\n%s", text)
+	}
+
+	text, errMsg = readResource(t, ctx, s, "slack://channel/C01234ABCDE/thread/1700000000.000100")
+	if errMsg != "" || !strings.Contains(text, "**@U1**") || !strings.Contains(text, "reply") || strings.Contains(text, "💬") {
+		t.Errorf("thread = %q (error %q)", text, errMsg)
+	}
+}
+
+// --- M53: Slack のエラーはヒント付きのリソース読み取りエラーになる ---
+func TestServer_ResourceError(t *testing.T) {
+	mock := &slackclient.MockClient{
+		GetHistoryFunc: func(ctx context.Context, channel string, opts slackclient.HistoryOptions) (*slackclient.HistoryResult, error) {
+			return nil, apperr.New(apperr.CodeChannelNotFound, "チャンネルが見つかりません: "+channel, nil)
+		},
+	}
+	s := NewServerWithClient(&config.Config{Token: "xoxb-test"}, mock)
+
+	_, errMsg := readResource(t, context.Background(), s, "slack://channel/unknown/history")
+	if !strings.Contains(errMsg, "channel_not_found") {
+		t.Errorf("error = %q, want channel_not_found", errMsg)
+	}
+}
+
+// --- M54: ワークスペースの振り分けと、トークンごとのツール制限・strict による非表示 ---
+func TestServer_ResourceAccess(t *testing.T) {
+	newMock := func(workspace string) *slackclient.MockClient {
+		return &slackclient.MockClient{
+			GetHistoryFunc: func(ctx context.Context, channel string, opts slackclient.HistoryOptions) (*slackclient.HistoryResult, error) {
+				return &slackclient.HistoryResult{Channel: "C01234ABCDE", ChannelName: workspace}, nil
+			},
+		}
+	}
+	cfg := &config.Config{
+		Token:    "xoxb-internal",
+		Profiles: map[string]*config.Config{"customer": {Token: "xoxb-customer"}},
+	}
+	s := newServer(cfg, map[string]slackclient.SlackClient{"": newMock("internal"), "customer": newMock("customer")}, logging.Discard())
+	ctx := context.Background()
+
+	uris, templates := listResources(t, s)
+	if strings.Join(uris, ",") != channelsURI+","+channelsURI+"?workspace=customer" {
+		t.Errorf("resources = %v, want a channel list per workspace", uris)
+	}
+	if len(templates) != 2 || !strings.HasSuffix(templates[0], "{?workspace}") {
+		t.Errorf("resource templates = %v, want workspace query", templates)
+	}
+	if text, _ := readResource(t, ctx, s, "slack://channel/general/history"); !strings.Contains(text, "#internal") {
+		t.Errorf("default workspace history = %q", text)
+	}
+	if text, _ := readResource(t, ctx, s, "slack://channel/general/history?workspace=customer"); !strings.Contains(text, "#customer") {
+		t.Errorf("customer workspace history = %q", text)
+	}
+	if _, errMsg := readResource(t, ctx, s, "slack://channel/general/history?workspace=partner"); !strings.Contains(errMsg, "profile_not_found") {
+		t.Errorf("unknown workspace error = %q", errMsg)
+	}
+
+	restricted := withAllowedTools(ctx, map[string]bool{"slack_get_thread": true})
+	if _, errMsg := readResource(t, restricted, s, "slack://channel/general/history"); !strings.Contains(errMsg, "tool_not_allowed") {
+		t.Errorf("restricted history error = %q, want tool_not_allowed", errMsg)
+	}
+
+	strict := &config.Config{Token: "xoxb-test", ToolRegistration: config.ToolRegistrationStrict}
+	s = newServer(strict, map[string]slackclient.SlackClient{"": &slackclient.MockClient{
+		AuthTestFunc: func(ctx context.Context) (*slackclient.AuthInfo, error) {
+			return &slackclient.AuthInfo{Scopes: []string{"chat:write"}}, nil
+		},
+	}}, logging.Discard())
+	if uris, templates := listResources(t, s); len(uris) != 0 || len(templates) != 0 {
+		t.Errorf("strict mode should not expose resources for hidden tools: %v %v", uris, templates)
+	}
+}
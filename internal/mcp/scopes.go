@@ -0,0 +1,187 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ToolScopes はツール1つの呼び出しに必要な OAuth スコープ。
+type ToolScopes struct {
+	Tool string `json:"tool"`
+	// Bot は Bot トークンに必要なスコープ。
+	Bot []string `json:"bot,omitempty"`
+	// User はユーザートークン（user_token）に必要なスコープ。
+	User []string `json:"user,omitempty"`
+}
+
+// requiredScopes はツールの登録順に並べた必要スコープ。
+// チャンネル名での指定（#general 等）を解決するため、チャンネルを受け取るツールは channels:read も必要とする。
+// 非公開チャンネルで使う場合の groups:* は任意のため含めない。
+var requiredScopes = []ToolScopes{
+	{Tool: "slack_post_message", Bot: []string{"chat:write", "channels:read"}},
+	{Tool: "slack_get_history", Bot: []string{"channels:history", "channels:read"}},
+	{Tool: "slack_post_thread", Bot: []string{"chat:write", "channels:read"}},
+	{Tool: "slack_get_thread", Bot: []string{"channels:history", "channels:read"}},
+	{Tool: "slack_update_message", Bot: []string{"chat:write", "channels:read"}},
+	{Tool: "slack_delete_message", Bot: []string{"chat:write", "channels:read"}},
+	{Tool: "slack_upload_file", Bot: []string{"files:write", "channels:read"}},
+	{Tool: "slack_search_messages", User: []string{"search:read"}},
+	{Tool: "slack_list_channels", Bot: []string{"channels:read"}},
+	{Tool: "slack_add_reaction", Bot: []string{"reactions:write", "channels:read"}},
+	{Tool: "slack_remove_reaction", Bot: []string{"reactions:write", "channels:read"}},
+	{Tool: "slack_send_dm", Bot: []string{"chat:write", "im:write", "users:read"}},
+	{Tool: "slack_schedule_message", Bot: []string{"chat:write", "channels:read"}},
+	{Tool: "slack_list_scheduled_messages"},
+	{Tool: "slack_delete_scheduled_message", Bot: []string{"chat:write", "channels:read"}},
+}
+
+// RequiredScopes はツールの登録順に各ツールの必要スコープを返す。
+func RequiredScopes() []ToolScopes {
+	return slices.Clone(requiredScopes)
+}
+
+// MissingScopes は required のうち granted に含まれないスコープを返す。
+func MissingScopes(required, granted []string) []string {
+	var missing []string
+	for _, scope := range required {
+		if !slices.Contains(granted, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// scopeDetectTimeout は起動時に各ワークスペースのスコープを取得する auth.test のタイムアウト。
+const scopeDetectTimeout = 10 * time.Second
+
+// missing はワークスペース（設定 cfg・auth.test の結果 info）でツールを呼び出すのに不足しているものを返す。
+// ユーザートークンが必要なツールでユーザートークンが未設定の場合は "user_token" を含める。
+func (ts ToolScopes) missing(cfg *config.Config, info *slackclient.AuthInfo) []string {
+	missing := MissingScopes(ts.Bot, info.Scopes)
+	if len(ts.User) > 0 {
+		switch {
+		case cfg.UserToken == "":
+			missing = append(missing, "user_token ("+strings.Join(ts.User, ", ")+")")
+		case info.UserScopes != nil:
+			missing = append(missing, MissingScopes(ts.User, info.UserScopes)...)
+		}
+	}
+	return missing
+}
+
+// scopeGate は tool_registration に従い、トークンのスコープが不足しているツールを
+// 登録しない（strict）、または説明に不足スコープを注記する（annotate）。
+type scopeGate struct {
+	mode    string
+	ws      *workspaces
+	scopes  map[string]ToolScopes            // ツール名 → 必要スコープ
+	granted map[string]*slackclient.AuthInfo // ワークスペース名 → auth.test の結果（スコープを取得できたもののみ）
+	logger  *slog.Logger
+}
+
+// newScopeGate は各ワークスペースのトークンのスコープを auth.test で取得して scopeGate を作成する。
+// mode が all の場合は取得しない。取得できなかったワークスペースは全ツールを使えるものとして扱う。
+func newScopeGate(mode string, ws *workspaces, logger *slog.Logger) *scopeGate {
+	g := &scopeGate{
+		mode:    mode,
+		ws:      ws,
+		scopes:  make(map[string]ToolScopes, len(requiredScopes)),
+		granted: make(map[string]*slackclient.AuthInfo),
+		logger:  logger,
+	}
+	for _, ts := range requiredScopes {
+		g.scopes[ts.Tool] = ts
+	}
+	if mode == config.ToolRegistrationAll {
+		return g
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), scopeDetectTimeout)
+	defer cancel()
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, client := range ws.clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, err := client.AuthTest(ctx)
+			switch {
+			case err != nil:
+				logger.Warn("could not detect token scopes; registering all tools", "workspace", name, "error", err)
+			case info.Scopes == nil:
+				logger.Debug("token scopes unknown; registering all tools", "workspace", name)
+			default:
+				mu.Lock()
+				g.granted[name] = info
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return g
+}
+
+// apply はツールを登録するかどうかを判定し、必要に応じて説明に不足スコープを注記したツールを返す。
+// strict の場合、全ワークスペースで使えないツールのみ登録しない（一部で使えないものは注記する）。
+func (g *scopeGate) apply(t mcp.Tool) (mcp.Tool, bool) {
+	ts, ok := g.scopes[t.Name]
+	if !ok || len(g.granted) == 0 {
+		return t, true
+	}
+
+	var notes []string
+	for _, name := range g.workspaceOrder() {
+		info, known := g.granted[name]
+		if !known {
+			continue
+		}
+		missing := ts.missing(g.ws.configs[name], info)
+		if len(missing) == 0 {
+			continue
+		}
+		note := "missing " + strings.Join(missing, ", ")
+		if len(g.ws.names) > 0 {
+			note = fmt.Sprintf("workspace '%s': %s", workspaceLabel(name), note)
+		}
+		notes = append(notes, note)
+	}
+	if len(notes) == 0 {
+		return t, true
+	}
+
+	if g.mode == config.ToolRegistrationStrict && len(notes) == len(g.ws.clients) {
+		g.logger.Info("tool not registered: token lacks required scopes", "tool", t.Name, "missing", strings.Join(notes, "; "))
+		return t, false
+	}
+	t.Description += fmt.Sprintf("\n\nUnavailable with the current token (%s); calls will fail with missing_scope until the scope is added to the Slack app.",
+		strings.Join(notes, "; "))
+	return t, true
+}
+
+// workspaceOrder はワークスペース名を注記する順（トップレベルの設定、プロファイル名順）で返す。
+func (g *scopeGate) workspaceOrder() []string {
+	var names []string
+	if _, ok := g.ws.clients[""]; ok {
+		names = append(names, "")
+	}
+	return append(names, g.ws.names...)
+}
+
+// workspaceLabel は注記に表示するワークスペース名を返す（トップレベルの設定は "default"）。
+func workspaceLabel(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
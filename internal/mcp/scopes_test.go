@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	"github.com/kai-kou/slack-fast-mcp/internal/logging"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+)
+
+// --- M50: 登録済みの全ツールに必要スコープが定義されている ---
+func TestRequiredScopes_CoverAllTools(t *testing.T) {
+	s := NewServerWithClient(&config.Config{Token: "xoxb-test"}, &slackclient.MockClient{})
+
+	defined := make(map[string]bool)
+	for _, ts := range RequiredScopes() {
+		if defined[ts.Tool] {
+			t.Errorf("duplicate entry for %s", ts.Tool)
+		}
+		defined[ts.Tool] = true
+		if s.GetTool(ts.Tool) == nil {
+			t.Errorf("%s is not a registered tool", ts.Tool)
+		}
+	}
+	for name := range s.ListTools() {
+		if !defined[name] {
+			t.Errorf("no required scopes defined for %s", name)
+		}
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	got := MissingScopes([]string{"chat:write", "channels:read", "im:write"}, []string{"channels:read", "chat:write"})
+	if !slices.Equal(got, []string{"im:write"}) {
+		t.Errorf("MissingScopes() = %v, want [im:write]", got)
+	}
+	if got := MissingScopes([]string{"chat:write"}, []string{"chat:write"}); got != nil {
+		t.Errorf("MissingScopes() = %v, want nil", got)
+	}
+}
+
+// --- M51: tool_registration に従い、スコープが不足しているツールを非表示・注記する ---
+func TestServer_ScopeAwareRegistration(t *testing.T) {
+	var authCalls atomic.Int32
+	newMock := func(scopes ...string) *slackclient.MockClient {
+		return &slackclient.MockClient{
+			AuthTestFunc: func(ctx context.Context) (*slackclient.AuthInfo, error) {
+				authCalls.Add(1)
+				return &slackclient.AuthInfo{Scopes: scopes}, nil
+			},
+		}
+	}
+	base := []string{"chat:write", "channels:read", "channels:history", "files:write", "im:write", "users:read"}
+
+	t.Run("annotate", func(t *testing.T) {
+		s := newServer(&config.Config{Token: "xoxb-test"}, map[string]slackclient.SlackClient{"": newMock(base...)}, logging.Discard())
+		if len(s.ListTools()) != len(requiredScopes) {
+			t.Errorf("registered %d tools, want all %d", len(s.ListTools()), len(requiredScopes))
+		}
+		if desc := s.GetTool("slack_add_reaction").Tool.Description; !strings.Contains(desc, "missing reactions:write") {
+			t.Errorf("slack_add_reaction description not annotated: %q", desc)
+		}
+		if desc := s.GetTool("slack_search_messages").Tool.Description; !strings.Contains(desc, "missing user_token (search:read)") {
+			t.Errorf("slack_search_messages description not annotated: %q", desc)
+		}
+		if desc := s.GetTool("slack_post_message").Tool.Description; strings.Contains(desc, "Unavailable") {
+			t.Errorf("slack_post_message should not be annotated: %q", desc)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		cfg := &config.Config{Token: "xoxb-test", ToolRegistration: config.ToolRegistrationStrict}
+		s := newServer(cfg, map[string]slackclient.SlackClient{"": newMock(base...)}, logging.Discard())
+		for _, name := range []string{"slack_add_reaction", "slack_remove_reaction", "slack_search_messages"} {
+			if s.GetTool(name) != nil {
+				t.Errorf("%s should not be registered", name)
+			}
+		}
+		if s.GetTool("slack_post_message") == nil {
+			t.Error("slack_post_message should be registered")
+		}
+	})
+
+	t.Run("strict keeps tools usable in some workspace", func(t *testing.T) {
+		cfg := &config.Config{
+			Token:            "xoxb-test",
+			ToolRegistration: config.ToolRegistrationStrict,
+			Profiles:         map[string]*config.Config{"customer": {Token: "xoxb-customer"}},
+		}
+		s := newServer(cfg, map[string]slackclient.SlackClient{
+			"":         newMock(base...),
+			"customer": newMock(append(base, "reactions:write")...),
+		}, logging.Discard())
+		tool := s.GetTool("slack_add_reaction")
+		if tool == nil {
+			t.Fatal("slack_add_reaction should be registered")
+		}
+		if desc := tool.Tool.Description; !strings.Contains(desc, "workspace 'default': missing reactions:write") || strings.Contains(desc, "'customer':") {
+			t.Errorf("slack_add_reaction description = %q", desc)
+		}
+	})
+
+	t.Run("all skips detection", func(t *testing.T) {
+		authCalls.Store(0)
+		cfg := &config.Config{Token: "xoxb-test", ToolRegistration: config.ToolRegistrationAll}
+		s := newServer(cfg, map[string]slackclient.SlackClient{"": newMock()}, logging.Discard())
+		if authCalls.Load() != 0 {
+			t.Errorf("auth.test called %d times, want 0", authCalls.Load())
+		}
+		if len(s.ListTools()) != len(requiredScopes) {
+			t.Errorf("registered %d tools, want all %d", len(s.ListTools()), len(requiredScopes))
+		}
+	})
+
+	t.Run("detection failure registers all tools", func(t *testing.T) {
+		cfg := &config.Config{Token: "xoxb-test", ToolRegistration: config.ToolRegistrationStrict}
+		failing := &slackclient.MockClient{
+			AuthTestFunc: func(ctx context.Context) (*slackclient.AuthInfo, error) {
+				return nil, errors.New("network down")
+			},
+		}
+		s := newServer(cfg, map[string]slackclient.SlackClient{"": failing}, logging.Discard())
+		if len(s.ListTools()) != len(requiredScopes) {
+			t.Errorf("registered %d tools, want all %d", len(s.ListTools()), len(requiredScopes))
+		}
+	})
+}
@@ -2,8 +2,15 @@
 package mcp
 
 import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
 	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	"github.com/kai-kou/slack-fast-mcp/internal/logging"
 	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -11,25 +18,101 @@ import (
 var Version = "dev"
 
 // NewServer は新しいMCP Serverを作成し、全ツールを登録する。
+// プロファイルが設定されている場合はワークスペース（プロファイル）ごとに Slack クライアントを作成し、
+// ツールの workspace パラメータで切り替える。
+// ログは log_level・log_format に従って stderr に出力する（stdout は MCP プロトコル専用）。
 func NewServer(cfg *config.Config) *server.MCPServer {
-	client := slackclient.NewClient(cfg.Token)
-	return NewServerWithClient(cfg, client)
+	logger := NewLogger(cfg, os.Stderr)
+	clients := make(map[string]slackclient.SlackClient)
+	for _, name := range workspaceNames(cfg) {
+		wcfg, err := cfg.Profile(name)
+		if err != nil {
+			// Validate 済みのため通常は発生しない
+			logger.Warn("skipping workspace profile", "profile", name, "error", err)
+			continue
+		}
+		clientLogger := NewLogger(wcfg, os.Stderr)
+		if name != "" {
+			clientLogger = clientLogger.With("workspace", name)
+		}
+		clients[name] = newWorkspaceClient(wcfg, clientLogger)
+	}
+	return newServer(cfg, clients, logger)
+}
+
+// warmUpTimeout は起動時のチャンネル索引構築（warm_channel_index）の制限時間。
+const warmUpTimeout = 2 * time.Minute
+
+// newWorkspaceClient はワークスペース1つ分の Slack クライアントを作成する。
+func newWorkspaceClient(cfg *config.Config, logger *slog.Logger) *slackclient.Client {
+	client := slackclient.NewClient(cfg.Token, ClientOptions(cfg, logger)...)
+	if cfg.WarmChannelIndexEnabled() {
+		// 最初のツール呼び出しで全チャンネルを走査しないよう、起動時に索引を構築しておく（ベストエフォート）。
+		// 走査のページ数は channel_scan_max_pages、時間は warmUpTimeout で打ち切る
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), warmUpTimeout)
+			defer cancel()
+			if n, err := client.WarmChannelIndex(ctx); err != nil {
+				logger.Warn("channel index warm-up failed", "error", err)
+			} else {
+				logger.Info("channel index warmed", "channels", n)
+			}
+		}()
+	}
+	return client
 }
 
 // NewServerWithClient は指定されたSlackClientを使用してMCP Serverを作成する（テスト用）。
+// ログは出力しない。
 func NewServerWithClient(cfg *config.Config, client slackclient.SlackClient) *server.MCPServer {
+	return newServer(cfg, map[string]slackclient.SlackClient{"": client}, logging.Discard())
+}
+
+// newServer は MCP Server を作成し、全ツール・リソース・プロンプトを登録する。
+// clients のキーはプロファイル名（トップレベルの設定は空文字）。
+// tool_registration が all 以外の場合は起動時に auth.test で各トークンのスコープを確認する。
+func newServer(cfg *config.Config, clients map[string]slackclient.SlackClient, logger *slog.Logger) *server.MCPServer {
 	s := server.NewMCPServer(
 		"slack-fast-mcp",
 		Version,
 		server.WithToolCapabilities(false),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
+		// HTTP トランスポートの Bearer トークンごとのツール制限（stdio では常に全許可）
+		server.WithToolFilter(filterAllowedTools),
+		// 拒否されたツール呼び出しも記録するため、ログを外側に置く
+		server.WithToolHandlerMiddleware(logToolCall(logger)),
+		server.WithToolHandlerMiddleware(requireAllowedTool),
 	)
+	ws := newWorkspaces(cfg, clients)
+
+	// ツール登録（tool_registration に従い、トークンのスコープが不足しているツールは登録しない・注記する）
+	gate := newScopeGate(cfg.ToolRegistrationMode(), ws, logger)
+	addTool := func(t mcp.Tool, newHandler func(slackclient.SlackClient, *config.Config) server.ToolHandlerFunc) {
+		if t, ok := gate.apply(ws.tool(t)); ok {
+			s.AddTool(t, ws.handler(newHandler))
+		}
+	}
+	addTool(postMessageTool(), postMessageHandler)
+	addTool(getHistoryTool(), getHistoryHandler)
+	addTool(postThreadTool(), postThreadHandler)
+	addTool(getThreadTool(), getThreadHandler)
+	addTool(updateMessageTool(), updateMessageHandler)
+	addTool(deleteMessageTool(), deleteMessageHandler)
+	addTool(uploadFileTool(), uploadFileHandler)
+	addTool(searchMessagesTool(), searchMessagesHandler)
+	addTool(listChannelsTool(), listChannelsHandler)
+	addTool(addReactionTool(), addReactionHandler)
+	addTool(removeReactionTool(), removeReactionHandler)
+	addTool(sendDMTool(), sendDMHandler)
+	addTool(scheduleMessageTool(), scheduleMessageHandler)
+	addTool(listScheduledMessagesTool(), listScheduledMessagesHandler)
+	addTool(deleteScheduledMessageTool(), deleteScheduledMessageHandler)
 
-	// ツール登録
-	s.AddTool(postMessageTool(), postMessageHandler(client, cfg))
-	s.AddTool(getHistoryTool(), getHistoryHandler(client, cfg))
-	s.AddTool(postThreadTool(), postThreadHandler(client, cfg))
-	s.AddTool(addReactionTool(), addReactionHandler(client, cfg))
-	s.AddTool(removeReactionTool(), removeReactionHandler(client, cfg))
+	// リソース登録（チャンネル一覧・履歴・スレッドを読みやすいテキストで公開する）
+	registerResources(s, ws)
+	// プロンプト登録（組み込みのプロンプトと設定の prompts。取得時に履歴を埋め込む）
+	registerPrompts(s, ws, cfg.Prompts)
 
 	return s
 }
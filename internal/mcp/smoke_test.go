@@ -33,7 +33,7 @@ func TestSmoke_AllToolsCallable(t *testing.T) {
 	threadCalled := false
 
 	mock := &slackclient.MockClient{
-		PostMessageFunc: func(ctx context.Context, channel, message string) (*slackclient.PostResult, error) {
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			postCalled = true
 			return &slackclient.PostResult{
 				Channel: "C01234", TS: "123.456", Message: message,
@@ -45,7 +45,7 @@ func TestSmoke_AllToolsCallable(t *testing.T) {
 				Channel: "C01234", Count: 0, Messages: []slackclient.HistoryMessage{},
 			}, nil
 		},
-		PostThreadFunc: func(ctx context.Context, channel, threadTS, message string) (*slackclient.PostResult, error) {
+		PostThreadFunc: func(ctx context.Context, channel, threadTS, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			threadCalled = true
 			return &slackclient.PostResult{
 				Channel: "C01234", TS: "123.789", ThreadTS: threadTS, Message: message,
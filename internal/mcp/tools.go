@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/kai-kou/slack-fast-mcp/internal/config"
 	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
@@ -22,7 +25,8 @@ func postMessageTool() mcp.Tool {
 			"If channel is omitted, posts to the configured default channel. "+
 			"The bot must be invited to the target channel first."),
 		mcp.WithString("channel",
-			mcp.Description("Channel name (e.g. 'general') or channel ID (e.g. 'C01234ABCDE'). "+
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
 				"If omitted, uses the configured default channel."),
 		),
 		mcp.WithString("message",
@@ -30,11 +34,22 @@ func postMessageTool() mcp.Tool {
 			mcp.Description("Message text to post. Supports Slack mrkdwn: "+
 				"*bold*, _italic_, `code`, ```code block```, <url|text>."),
 		),
+		mcp.WithArray("blocks",
+			mcp.Description("Optional Block Kit blocks (JSON array, e.g. [{\"type\":\"section\",\"text\":{\"type\":\"mrkdwn\",\"text\":\"*Build passed*\"}}]). "+
+				"Supported types: section, header, divider, context, image, actions, rich_text, markdown, etc. "+
+				"When set, 'message' is used as the notification fallback text."),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
 		mcp.WithString("display_name",
 			mcp.Description("Display name of the sender (e.g. AI agent persona name). "+
 				"If provided, appends #display_name hashtag to the message. "+
 				"Useful for identifying which AI persona posted the message."),
 		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Optional unique key for this post (e.g. a UUID). If a post with the same key succeeded recently, "+
+				"the original result is returned with \"replayed\": true instead of posting again. "+
+				"Set it so that retrying after a timeout cannot create a duplicate message."),
+		),
 	)
 }
 
@@ -54,23 +69,35 @@ func postMessageHandler(client slackclient.SlackClient, cfg *config.Config) serv
 			return handleAppError(err)
 		}
 
+		blocks, err := blocksParam(request)
+		if err != nil {
+			return handleAppError(err)
+		}
+
 		// display_name の解決（パラメータ > Config デフォルト）
 		displayName := cfg.ResolveDisplayName(displayNameParam)
 		message = appendDisplayNameTag(message, displayName)
 
-		result, err := client.PostMessage(ctx, channel, message)
+		result, err := client.PostMessage(ctx, channel, message, slackclient.PostOptions{
+			Blocks:         blocks,
+			IdempotencyKey: request.GetString("idempotency_key", ""),
+		})
 		if err != nil {
 			return handleAppError(err)
 		}
 
-		return toolResultJSON(map[string]any{
+		resp := map[string]any{
 			"ok":           true,
 			"channel":      result.Channel,
 			"channel_name": result.ChannelName,
 			"ts":           result.TS,
 			"message":      result.Message,
 			"permalink":    result.Permalink,
-		})
+		}
+		if result.Replayed {
+			resp["replayed"] = true
+		}
+		return toolResultJSON(resp)
 	}
 }
 
@@ -80,10 +107,12 @@ func getHistoryTool() mcp.Tool {
 	return mcp.NewTool("slack_get_history",
 		mcp.WithDescription("Get message history from a Slack channel. "+
 			"Returns recent messages with user names, timestamps, and permalinks. "+
+			"If has_more is true, pass next_cursor as cursor to get the next (older) page. "+
 			"If channel is omitted, uses the configured default channel. "+
 			"The bot must be invited to the target channel first."),
 		mcp.WithString("channel",
-			mcp.Description("Channel name (e.g. 'general') or channel ID (e.g. 'C01234ABCDE'). "+
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
 				"If omitted, uses the configured default channel."),
 		),
 		mcp.WithNumber("limit",
@@ -95,6 +124,9 @@ func getHistoryTool() mcp.Tool {
 		mcp.WithString("latest",
 			mcp.Description("End of time range (Unix timestamp). Only messages before this time are included."),
 		),
+		mcp.WithString("cursor",
+			mcp.Description("Pagination cursor. Pass the 'next_cursor' value from a previous response to get older messages."),
+		),
 	)
 }
 
@@ -110,11 +142,13 @@ func getHistoryHandler(client slackclient.SlackClient, cfg *config.Config) serve
 		limit := request.GetInt("limit", 10)
 		oldest := request.GetString("oldest", "")
 		latest := request.GetString("latest", "")
+		cursor := request.GetString("cursor", "")
 
 		opts := slackclient.HistoryOptions{
 			Limit:  limit,
 			Oldest: oldest,
 			Latest: latest,
+			Cursor: cursor,
 		}
 
 		result, err := client.GetHistory(ctx, channel, opts)
@@ -128,6 +162,73 @@ func getHistoryHandler(client slackclient.SlackClient, cfg *config.Config) serve
 			"channel_name": result.ChannelName,
 			"messages":     result.Messages,
 			"has_more":     result.HasMore,
+			"next_cursor":  result.NextCursor,
+			"count":        result.Count,
+		})
+	}
+}
+
+// --- slack_get_thread ---
+
+func getThreadTool() mcp.Tool {
+	return mcp.NewTool("slack_get_thread",
+		mcp.WithDescription("Get replies in a Slack message thread. "+
+			"Returns the parent message followed by its replies, with user names, timestamps, and permalinks. "+
+			"Use this before replying with slack_post_thread to read the conversation. "+
+			"If channel is omitted, uses the configured default channel. "+
+			"The bot must be invited to the target channel first."),
+		mcp.WithString("channel",
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
+				"If omitted, uses the configured default channel."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Required(),
+			mcp.Description("Timestamp of the parent message (e.g. '1234567890.123456'). "+
+				"Get this from the 'ts' or 'thread_ts' field of slack_get_history results."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Number of messages to retrieve per page (1-200). Defaults to 50."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Pagination cursor. Pass the 'next_cursor' value from a previous response to get the next page."),
+		),
+	)
+}
+
+func getThreadHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		channelParam := request.GetString("channel", "")
+		threadTS := request.GetString("thread_ts", "")
+
+		if threadTS == "" {
+			appErr := apperr.New(apperr.CodeThreadNotFound, "thread_ts が指定されていません", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+
+		channel, err := cfg.ResolveChannel(channelParam)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		opts := slackclient.ThreadOptions{
+			Limit:  request.GetInt("limit", 50),
+			Cursor: request.GetString("cursor", ""),
+		}
+
+		result, err := client.GetThreadReplies(ctx, channel, threadTS, opts)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":           true,
+			"channel":      result.Channel,
+			"channel_name": result.ChannelName,
+			"thread_ts":    result.ThreadTS,
+			"messages":     result.Messages,
+			"has_more":     result.HasMore,
+			"next_cursor":  result.NextCursor,
 			"count":        result.Count,
 		})
 	}
@@ -142,7 +243,8 @@ func postThreadTool() mcp.Tool {
 			"If channel is omitted, uses the configured default channel. "+
 			"The bot must be invited to the target channel first."),
 		mcp.WithString("channel",
-			mcp.Description("Channel name (e.g. 'general') or channel ID (e.g. 'C01234ABCDE'). "+
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
 				"If omitted, uses the configured default channel."),
 		),
 		mcp.WithString("thread_ts",
@@ -155,11 +257,22 @@ func postThreadTool() mcp.Tool {
 			mcp.Description("Reply message text to post. Supports Slack mrkdwn: "+
 				"*bold*, _italic_, `code`, ```code block```, <url|text>."),
 		),
+		mcp.WithArray("blocks",
+			mcp.Description("Optional Block Kit blocks (JSON array, e.g. [{\"type\":\"section\",\"text\":{\"type\":\"mrkdwn\",\"text\":\"*Build passed*\"}}]). "+
+				"Supported types: section, header, divider, context, image, actions, rich_text, markdown, etc. "+
+				"When set, 'message' is used as the notification fallback text."),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
 		mcp.WithString("display_name",
 			mcp.Description("Display name of the sender (e.g. AI agent persona name). "+
 				"If provided, appends #display_name hashtag to the message. "+
 				"Useful for identifying which AI persona posted the message."),
 		),
+		mcp.WithString("idempotency_key",
+			mcp.Description("Optional unique key for this post (e.g. a UUID). If a post with the same key succeeded recently, "+
+				"the original result is returned with \"replayed\": true instead of posting again. "+
+				"Set it so that retrying after a timeout cannot create a duplicate message."),
+		),
 	)
 }
 
@@ -185,16 +298,24 @@ func postThreadHandler(client slackclient.SlackClient, cfg *config.Config) serve
 			return handleAppError(err)
 		}
 
+		blocks, err := blocksParam(request)
+		if err != nil {
+			return handleAppError(err)
+		}
+
 		// display_name の解決（パラメータ > Config デフォルト）
 		displayName := cfg.ResolveDisplayName(displayNameParam)
 		message = appendDisplayNameTag(message, displayName)
 
-		result, err := client.PostThread(ctx, channel, threadTS, message)
+		result, err := client.PostThread(ctx, channel, threadTS, message, slackclient.PostOptions{
+			Blocks:         blocks,
+			IdempotencyKey: request.GetString("idempotency_key", ""),
+		})
 		if err != nil {
 			return handleAppError(err)
 		}
 
-		return toolResultJSON(map[string]any{
+		resp := map[string]any{
 			"ok":           true,
 			"channel":      result.Channel,
 			"channel_name": result.ChannelName,
@@ -202,6 +323,369 @@ func postThreadHandler(client slackclient.SlackClient, cfg *config.Config) serve
 			"thread_ts":    result.ThreadTS,
 			"message":      result.Message,
 			"permalink":    result.Permalink,
+		}
+		if result.Replayed {
+			resp["replayed"] = true
+		}
+		return toolResultJSON(resp)
+	}
+}
+
+// --- slack_update_message ---
+
+func updateMessageTool() mcp.Tool {
+	return mcp.NewTool("slack_update_message",
+		mcp.WithDescription("Edit a message previously posted by the bot (e.g. to correct a status line). "+
+			"Replaces the whole message text. Supports Slack mrkdwn formatting. "+
+			"If channel is omitted, uses the configured default channel."),
+		mcp.WithString("channel",
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
+				"If omitted, uses the configured default channel."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to edit (e.g. '1234567890.123456'). "+
+				"Get this from the 'ts' field of slack_post_message or slack_post_thread results."),
+		),
+		mcp.WithString("message",
+			mcp.Required(),
+			mcp.Description("New message text. Supports Slack mrkdwn: "+
+				"*bold*, _italic_, `code`, ```code block```, <url|text>."),
+		),
+		mcp.WithString("display_name",
+			mcp.Description("Display name of the sender (e.g. AI agent persona name). "+
+				"If provided, appends #display_name hashtag to the message, as slack_post_message does."),
+		),
+	)
+}
+
+func updateMessageHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		channelParam := request.GetString("channel", "")
+		timestamp := request.GetString("timestamp", "")
+		message := request.GetString("message", "")
+		displayNameParam := request.GetString("display_name", "")
+
+		if message == "" {
+			appErr := apperr.New(apperr.CodeNoText, "メッセージが空です", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+
+		if timestamp == "" {
+			appErr := apperr.New(apperr.CodeMessageNotFound, "timestamp が指定されていません", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+
+		channel, err := cfg.ResolveChannel(channelParam)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		// 編集後もペルソナのハッシュタグを維持する
+		displayName := cfg.ResolveDisplayName(displayNameParam)
+		message = appendDisplayNameTag(message, displayName)
+
+		result, err := client.UpdateMessage(ctx, channel, timestamp, message)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":           true,
+			"channel":      result.Channel,
+			"channel_name": result.ChannelName,
+			"ts":           result.TS,
+			"message":      result.Message,
+			"permalink":    result.Permalink,
+		})
+	}
+}
+
+// --- slack_delete_message ---
+
+func deleteMessageTool() mcp.Tool {
+	return mcp.NewTool("slack_delete_message",
+		mcp.WithDescription("Delete a message previously posted by the bot. "+
+			"This cannot be undone. "+
+			"If channel is omitted, uses the configured default channel."),
+		mcp.WithString("channel",
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
+				"If omitted, uses the configured default channel."),
+		),
+		mcp.WithString("timestamp",
+			mcp.Required(),
+			mcp.Description("Timestamp of the message to delete (e.g. '1234567890.123456'). "+
+				"Get this from the 'ts' field of slack_post_message or slack_post_thread results."),
+		),
+	)
+}
+
+func deleteMessageHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		channelParam := request.GetString("channel", "")
+		timestamp := request.GetString("timestamp", "")
+
+		if timestamp == "" {
+			appErr := apperr.New(apperr.CodeMessageNotFound, "timestamp が指定されていません", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+
+		channel, err := cfg.ResolveChannel(channelParam)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		result, err := client.DeleteMessage(ctx, channel, timestamp)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":           true,
+			"channel":      result.Channel,
+			"channel_name": result.ChannelName,
+			"ts":           result.TS,
+		})
+	}
+}
+
+// --- slack_upload_file ---
+
+func uploadFileTool() mcp.Tool {
+	return mcp.NewTool("slack_upload_file",
+		mcp.WithDescription("Upload a file or text snippet (e.g. build log, diff, generated report) to a Slack channel or thread. "+
+			"Pass the text inline via 'content', or a local file via 'path' "+
+			"(only files under the directories allowed by upload_dirs in the config). "+
+			"If channel is omitted, uses the configured default channel. "+
+			"The bot must be invited to the target channel first."),
+		mcp.WithString("channel",
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
+				"If omitted, uses the configured default channel."),
+		),
+		mcp.WithString("content",
+			mcp.Description("File content as text. Use this or 'path', not both."),
+		),
+		mcp.WithString("path",
+			mcp.Description("Path of a local file to upload. Must be inside a directory listed in upload_dirs. "+
+				"Use this or 'content', not both."),
+		),
+		mcp.WithString("filename",
+			mcp.Description("File name shown in Slack (e.g. 'build.log'). "+
+				"Defaults to the base name of 'path', or 'snippet.txt' for inline content."),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title of the file. Defaults to the file name."),
+		),
+		mcp.WithString("snippet_type",
+			mcp.Description("Syntax type for text snippets (e.g. 'text', 'diff', 'go', 'python')."),
+		),
+		mcp.WithString("thread_ts",
+			mcp.Description("Timestamp of a parent message to share the file in its thread."),
+		),
+		mcp.WithString("initial_comment",
+			mcp.Description("Message text posted together with the file."),
+		),
+	)
+}
+
+func uploadFileHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		channelParam := request.GetString("channel", "")
+		content := request.GetString("content", "")
+		path := request.GetString("path", "")
+		filename := request.GetString("filename", "")
+
+		if (content == "") == (path == "") {
+			appErr := apperr.New(apperr.CodeNoFileContent, "content と path のどちらか一方を指定してください", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+
+		data := []byte(content)
+		if path != "" {
+			var err error
+			data, err = readUploadFile(cfg.UploadDirs, path)
+			if err != nil {
+				return handleAppError(err)
+			}
+			if filename == "" {
+				filename = filepath.Base(path)
+			}
+		}
+		if filename == "" {
+			filename = "snippet.txt"
+		}
+
+		channel, err := cfg.ResolveChannel(channelParam)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		result, err := client.UploadFile(ctx, channel, slackclient.UploadOptions{
+			Filename:       filename,
+			Title:          request.GetString("title", ""),
+			Content:        data,
+			ThreadTS:       request.GetString("thread_ts", ""),
+			InitialComment: request.GetString("initial_comment", ""),
+			SnippetType:    request.GetString("snippet_type", ""),
+		})
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":           true,
+			"channel":      result.Channel,
+			"channel_name": result.ChannelName,
+			"file_id":      result.FileID,
+			"filename":     result.Filename,
+			"title":        result.Title,
+			"size":         result.Size,
+			"thread_ts":    result.ThreadTS,
+			"permalink":    result.Permalink,
+		})
+	}
+}
+
+// --- slack_search_messages ---
+
+func searchMessagesTool() mcp.Tool {
+	return mcp.NewTool("slack_search_messages",
+		mcp.WithDescription("Search messages across the Slack workspace (like Slack's search box). "+
+			"Returns matching messages with channel, user names, timestamps, and permalinks. "+
+			"Use this to find where a topic was discussed, then slack_get_thread to read the full conversation. "+
+			"Requires a user token (user_token / SLACK_USER_TOKEN); bot tokens cannot search."),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Search terms. Slack search modifiers are supported "+
+				"(e.g. '\"exact phrase\"', 'has:link', 'is:thread')."),
+		),
+		mcp.WithString("channel",
+			mcp.Description("Limit results to a channel name (e.g. 'general') or channel ID (adds 'in:' modifier)."),
+		),
+		mcp.WithString("user",
+			mcp.Description("Limit results to messages from a user name (e.g. 'alice') or user ID (adds 'from:' modifier)."),
+		),
+		mcp.WithString("after",
+			mcp.Description("Only messages after this date (YYYY-MM-DD)."),
+		),
+		mcp.WithString("before",
+			mcp.Description("Only messages before this date (YYYY-MM-DD)."),
+		),
+		mcp.WithString("on",
+			mcp.Description("Only messages on this date (YYYY-MM-DD)."),
+		),
+		mcp.WithString("sort",
+			mcp.Description("Sort order: 'score' (relevance, default) or 'timestamp'."),
+			mcp.Enum("score", "timestamp"),
+		),
+		mcp.WithString("sort_dir",
+			mcp.Description("Sort direction: 'desc' (default) or 'asc'."),
+			mcp.Enum("desc", "asc"),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of results per page (1-100). Defaults to 20."),
+		),
+		mcp.WithNumber("page",
+			mcp.Description("Page number (1-100). Defaults to 1."),
+		),
+	)
+}
+
+func searchMessagesHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query := request.GetString("query", "")
+		if strings.TrimSpace(query) == "" {
+			appErr := apperr.New(apperr.CodeNoQuery, "検索クエリが空です", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+		if err := slackclient.ValidateSearchSort(request.GetString("sort", ""), request.GetString("sort_dir", "")); err != nil {
+			return handleAppError(err)
+		}
+
+		opts := slackclient.SearchOptions{
+			Query:   query,
+			Channel: request.GetString("channel", ""),
+			User:    request.GetString("user", ""),
+			After:   request.GetString("after", ""),
+			Before:  request.GetString("before", ""),
+			On:      request.GetString("on", ""),
+			Sort:    request.GetString("sort", ""),
+			SortDir: request.GetString("sort_dir", ""),
+			Count:   request.GetInt("count", 20),
+			Page:    request.GetInt("page", 1),
+		}
+
+		result, err := client.SearchMessages(ctx, opts)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":       true,
+			"query":    result.Query,
+			"messages": result.Messages,
+			"total":    result.Total,
+			"page":     result.Page,
+			"pages":    result.Pages,
+			"count":    result.Count,
+		})
+	}
+}
+
+// --- slack_list_channels ---
+
+func listChannelsTool() mcp.Tool {
+	return mcp.NewTool("slack_list_channels",
+		mcp.WithDescription("List channels in the Slack workspace with their IDs, member counts, topics and whether the bot is a member. "+
+			"Use this to discover the correct channel name or ID instead of guessing. "+
+			"Results are paginated; pass 'next_cursor' as 'cursor' to continue."),
+		mcp.WithString("types",
+			mcp.Description("Comma-separated conversation types: public, private, mpim, im "+
+				"(or public_channel, private_channel). Defaults to public and private channels."),
+		),
+		mcp.WithBoolean("member_only",
+			mcp.Description("Only list channels the bot is a member of (i.e. channels it can post to and read)."),
+		),
+		mcp.WithString("prefix",
+			mcp.Description("Only list channels whose name starts with this prefix (case-insensitive, e.g. 'proj-')."),
+		),
+		mcp.WithBoolean("include_archived",
+			mcp.Description("Include archived channels. Defaults to false."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Number of channels to retrieve (1-1000). Defaults to 100."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Pagination cursor. Pass the 'next_cursor' value from a previous response to get the next page."),
+		),
+	)
+}
+
+func listChannelsHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		opts := slackclient.ListChannelsOptions{
+			Types:           slackclient.ParseConversationTypes(request.GetString("types", "")),
+			MemberOnly:      request.GetBool("member_only", false),
+			Prefix:          request.GetString("prefix", ""),
+			IncludeArchived: request.GetBool("include_archived", false),
+			Limit:           request.GetInt("limit", 100),
+			Cursor:          request.GetString("cursor", ""),
+		}
+
+		result, err := client.ListChannels(ctx, opts)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":          true,
+			"channels":    result.Channels,
+			"has_more":    result.HasMore,
+			"next_cursor": result.NextCursor,
+			"count":       result.Count,
 		})
 	}
 }
@@ -216,7 +700,8 @@ func addReactionTool() mcp.Tool {
 			"The bot must be invited to the target channel first. "+
 			"Requires the 'reactions:write' OAuth scope."),
 		mcp.WithString("channel",
-			mcp.Description("Channel name (e.g. 'general') or channel ID (e.g. 'C01234ABCDE'). "+
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
 				"If omitted, uses the configured default channel."),
 		),
 		mcp.WithString("timestamp",
@@ -282,7 +767,8 @@ func removeReactionTool() mcp.Tool {
 			"The bot must be invited to the target channel first. "+
 			"Requires the 'reactions:write' OAuth scope."),
 		mcp.WithString("channel",
-			mcp.Description("Channel name (e.g. 'general') or channel ID (e.g. 'C01234ABCDE'). "+
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
 				"If omitted, uses the configured default channel."),
 		),
 		mcp.WithString("timestamp",
@@ -336,6 +822,248 @@ func removeReactionHandler(client slackclient.SlackClient, cfg *config.Config) s
 	}
 }
 
+// --- slack_send_dm ---
+
+func sendDMTool() mcp.Tool {
+	return mcp.NewTool("slack_send_dm",
+		mcp.WithDescription("Send a direct message to a Slack user. "+
+			"The user can be given as a username ('@alice' or 'alice'), an email address, or a user ID. "+
+			"Opens the DM conversation if needed (requires im:write; email lookup requires users:read.email)."),
+		mcp.WithString("user",
+			mcp.Required(),
+			mcp.Description("Recipient: username (e.g. '@alice'), email address (e.g. 'alice@example.com'), "+
+				"or user ID (e.g. 'U01234ABCDE')."),
+		),
+		mcp.WithString("message",
+			mcp.Required(),
+			mcp.Description("Message text to send. Supports Slack mrkdwn: "+
+				"*bold*, _italic_, `code`, ```code block```, <url|text>."),
+		),
+		mcp.WithArray("blocks",
+			mcp.Description("Optional Block Kit blocks (JSON array). "+
+				"When set, 'message' is used as the notification fallback text."),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
+		mcp.WithString("display_name",
+			mcp.Description("Display name of the sender (e.g. AI agent persona name). "+
+				"If provided, appends #display_name hashtag to the message."),
+		),
+	)
+}
+
+func sendDMHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		user := strings.TrimSpace(request.GetString("user", ""))
+		message := request.GetString("message", "")
+		displayNameParam := request.GetString("display_name", "")
+
+		if user == "" {
+			appErr := apperr.New(apperr.CodeUserNotFound, "送信先のユーザーが指定されていません", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+		if message == "" {
+			appErr := apperr.New(apperr.CodeNoText, "メッセージが空です", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+
+		// "@" なしのユーザー名はチャンネル名と区別できないため、ユーザー名として扱う
+		if !slackclient.IsDMTarget(user) {
+			user = "@" + user
+		}
+
+		blocks, err := blocksParam(request)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		displayName := cfg.ResolveDisplayName(displayNameParam)
+		message = appendDisplayNameTag(message, displayName)
+
+		result, err := client.PostMessage(ctx, user, message, slackclient.PostOptions{Blocks: blocks})
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":        true,
+			"user":      user,
+			"channel":   result.Channel,
+			"ts":        result.TS,
+			"message":   result.Message,
+			"permalink": result.Permalink,
+		})
+	}
+}
+
+// --- slack_schedule_message ---
+
+func scheduleMessageTool() mcp.Tool {
+	return mcp.NewTool("slack_schedule_message",
+		mcp.WithDescription("Schedule a message to be posted to a Slack channel at a future time (up to 120 days ahead). "+
+			"Returns a scheduled_message_id that can be used with slack_delete_scheduled_message to cancel it. "+
+			"If channel is omitted, uses the configured default channel."),
+		mcp.WithString("channel",
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
+				"If omitted, uses the configured default channel."),
+		),
+		mcp.WithString("message",
+			mcp.Required(),
+			mcp.Description("Message text to post. Supports Slack mrkdwn: "+
+				"*bold*, _italic_, `code`, ```code block```, <url|text>."),
+		),
+		mcp.WithString("post_at",
+			mcp.Required(),
+			mcp.Description("When to post: Unix timestamp (e.g. '1767225600'), RFC3339 (e.g. '2026-01-01T09:00:00+09:00'), "+
+				"or a relative duration from now (e.g. 'in 2h', 'in 30m', 'in 1d')."),
+		),
+		mcp.WithArray("blocks",
+			mcp.Description("Optional Block Kit blocks (JSON array). "+
+				"When set, 'message' is used as the notification fallback text."),
+			mcp.Items(map[string]any{"type": "object"}),
+		),
+		mcp.WithString("display_name",
+			mcp.Description("Display name of the sender (e.g. AI agent persona name). "+
+				"If provided, appends #display_name hashtag to the message."),
+		),
+	)
+}
+
+func scheduleMessageHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		channelParam := request.GetString("channel", "")
+		message := request.GetString("message", "")
+		displayNameParam := request.GetString("display_name", "")
+
+		if message == "" {
+			appErr := apperr.New(apperr.CodeNoText, "メッセージが空です", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+
+		postAt, err := slackclient.ParsePostAt(request.GetString("post_at", ""), time.Now())
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		channel, err := cfg.ResolveChannel(channelParam)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		blocks, err := blocksParam(request)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		displayName := cfg.ResolveDisplayName(displayNameParam)
+		message = appendDisplayNameTag(message, displayName)
+
+		result, err := client.ScheduleMessage(ctx, channel, message, postAt, slackclient.PostOptions{Blocks: blocks})
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":                   true,
+			"channel":              result.Channel,
+			"channel_name":         result.ChannelName,
+			"scheduled_message_id": result.ScheduledMessageID,
+			"post_at":              result.PostAt,
+			"message":              result.Message,
+		})
+	}
+}
+
+// --- slack_list_scheduled_messages ---
+
+func listScheduledMessagesTool() mcp.Tool {
+	return mcp.NewTool("slack_list_scheduled_messages",
+		mcp.WithDescription("List messages the bot has scheduled that have not been posted yet. "+
+			"Results are paginated; pass 'next_cursor' as 'cursor' to continue."),
+		mcp.WithString("channel",
+			mcp.Description("Only list scheduled messages for this channel (name or ID). "+
+				"If omitted, lists scheduled messages in all channels."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Number of scheduled messages to retrieve (1-1000). Defaults to 100."),
+		),
+		mcp.WithString("cursor",
+			mcp.Description("Pagination cursor. Pass the 'next_cursor' value from a previous response to get the next page."),
+		),
+	)
+}
+
+func listScheduledMessagesHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// 一覧は全チャンネルが対象になり得るため、デフォルトチャンネルにはフォールバックしない
+		result, err := client.ListScheduledMessages(ctx, slackclient.ListScheduledOptions{
+			Channel: request.GetString("channel", ""),
+			Limit:   request.GetInt("limit", 100),
+			Cursor:  request.GetString("cursor", ""),
+		})
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":                 true,
+			"scheduled_messages": result.Messages,
+			"has_more":           result.HasMore,
+			"next_cursor":        result.NextCursor,
+			"count":              result.Count,
+		})
+	}
+}
+
+// --- slack_delete_scheduled_message ---
+
+func deleteScheduledMessageTool() mcp.Tool {
+	return mcp.NewTool("slack_delete_scheduled_message",
+		mcp.WithDescription("Cancel a scheduled message before it is posted. "+
+			"The channel must be the one the message was scheduled in. "+
+			"If channel is omitted, uses the configured default channel."),
+		mcp.WithString("channel",
+			mcp.Description("Channel name (e.g. 'general'), channel ID (e.g. 'C01234ABCDE'), "+
+				"or a user to DM ('@alice', an email address, or a user ID 'U01234ABCDE'). "+
+				"If omitted, uses the configured default channel."),
+		),
+		mcp.WithString("scheduled_message_id",
+			mcp.Required(),
+			mcp.Description("ID of the scheduled message (e.g. 'Q1298393284'), "+
+				"from slack_schedule_message or slack_list_scheduled_messages."),
+		),
+	)
+}
+
+func deleteScheduledMessageHandler(client slackclient.SlackClient, cfg *config.Config) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		channelParam := request.GetString("channel", "")
+		scheduledID := request.GetString("scheduled_message_id", "")
+
+		if scheduledID == "" {
+			appErr := apperr.New(apperr.CodeScheduledMessageNotFound, "scheduled_message_id が指定されていません", nil)
+			return mcp.NewToolResultError(appErr.FormatForMCP()), nil
+		}
+
+		channel, err := cfg.ResolveChannel(channelParam)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		result, err := client.DeleteScheduledMessage(ctx, channel, scheduledID)
+		if err != nil {
+			return handleAppError(err)
+		}
+
+		return toolResultJSON(map[string]any{
+			"ok":                   true,
+			"channel":              result.Channel,
+			"channel_name":         result.ChannelName,
+			"scheduled_message_id": result.ScheduledMessageID,
+		})
+	}
+}
+
 // --- ヘルパー ---
 
 // normalizeEmojiName はコロン付きの絵文字名からコロンを除去する。
@@ -363,6 +1091,97 @@ func appendDisplayNameTag(message, displayName string) string {
 	return message + "\n" + tag
 }
 
+// blocksParam は blocks パラメータを Block Kit JSON 文字列として取り出し、検証する。
+// 配列のほか、JSON 文字列として渡された場合も受け付ける。未指定時は空文字を返す。
+func blocksParam(request mcp.CallToolRequest) (string, error) {
+	v, ok := request.GetArguments()["blocks"]
+	if !ok || v == nil {
+		return "", nil
+	}
+
+	var raw string
+	if str, isStr := v.(string); isStr {
+		raw = str
+	} else {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", apperr.New(apperr.CodeInvalidBlocks, "blocks を JSON に変換できません", err)
+		}
+		raw = string(b)
+	}
+	if strings.TrimSpace(raw) == "" {
+		return "", nil
+	}
+
+	if _, err := slackclient.ParseBlocks(raw); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// readUploadFile は許可ディレクトリ配下のローカルファイルを読み込む。
+// シンボリックリンクを解決したうえで判定し、".." やリンクによる許可ディレクトリ外への参照を拒否する。
+func readUploadFile(allowedDirs []string, path string) ([]byte, error) {
+	if len(allowedDirs) == 0 {
+		return nil, apperr.New(apperr.CodePathNotAllowed,
+			"ローカルファイルのアップロードは許可されていません（upload_dirs 未設定）", nil)
+	}
+
+	resolved, err := filepath.Abs(path)
+	if err == nil {
+		resolved, err = filepath.EvalSymlinks(resolved)
+	}
+	if err != nil {
+		return nil, apperr.New(apperr.CodeFileReadError,
+			fmt.Sprintf("ファイルを読み込めません: %s", path), err)
+	}
+
+	allowed := false
+	for _, dir := range allowedDirs {
+		if isWithinDir(dir, resolved) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, apperr.New(apperr.CodePathNotAllowed,
+			fmt.Sprintf("許可されていないパスです: %s", path), nil)
+	}
+
+	info, err := os.Stat(resolved)
+	if err == nil && !info.Mode().IsRegular() {
+		err = fmt.Errorf("not a regular file")
+	}
+	var data []byte
+	if err == nil {
+		data, err = os.ReadFile(resolved)
+	}
+	if err != nil {
+		return nil, apperr.New(apperr.CodeFileReadError,
+			fmt.Sprintf("ファイルを読み込めません: %s", path), err)
+	}
+	if len(data) == 0 {
+		return nil, apperr.New(apperr.CodeNoFileContent, fmt.Sprintf("ファイルが空です: %s", path), nil)
+	}
+	return data, nil
+}
+
+// isWithinDir は path（解決済みの絶対パス）が dir 配下かどうかを判定する。
+func isWithinDir(dir, path string) bool {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	if resolvedDir, err := filepath.EvalSymlinks(absDir); err == nil {
+		absDir = resolvedDir
+	}
+	rel, err := filepath.Rel(absDir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // handleAppError はエラーをMCPツールエラーに変換する。
 func handleAppError(err error) (*mcp.CallToolResult, error) {
 	if appErr, ok := err.(*apperr.AppError); ok {
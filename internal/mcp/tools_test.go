@@ -3,13 +3,17 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kai-kou/slack-fast-mcp/internal/config"
 	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
 	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 // newTestCallToolRequest はテスト用のCallToolRequestを作成する。
@@ -25,7 +29,7 @@ func newTestCallToolRequest(toolName string, args map[string]any) mcp.CallToolRe
 // --- M01: slack_post_message 正常系 ---
 func TestPostMessageHandler_Success(t *testing.T) {
 	mock := &slackclient.MockClient{
-		PostMessageFunc: func(ctx context.Context, channel, message string) (*slackclient.PostResult, error) {
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			return &slackclient.PostResult{
 				Channel:     "C01234ABCDE",
 				ChannelName: "general",
@@ -61,7 +65,7 @@ func TestPostMessageHandler_Success(t *testing.T) {
 func TestPostMessageHandler_WithChannel(t *testing.T) {
 	var capturedChannel string
 	mock := &slackclient.MockClient{
-		PostMessageFunc: func(ctx context.Context, channel, message string) (*slackclient.PostResult, error) {
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			capturedChannel = channel
 			return &slackclient.PostResult{
 				Channel: "C09876ZZZZZ",
@@ -92,7 +96,7 @@ func TestPostMessageHandler_WithChannel(t *testing.T) {
 func TestPostMessageHandler_DefaultChannel(t *testing.T) {
 	var capturedChannel string
 	mock := &slackclient.MockClient{
-		PostMessageFunc: func(ctx context.Context, channel, message string) (*slackclient.PostResult, error) {
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			capturedChannel = channel
 			return &slackclient.PostResult{
 				Channel: "C01234ABCDE",
@@ -228,7 +232,7 @@ func TestGetHistoryHandler_WithLimit(t *testing.T) {
 // --- M08: slack_post_thread 正常系 ---
 func TestPostThreadHandler_Success(t *testing.T) {
 	mock := &slackclient.MockClient{
-		PostThreadFunc: func(ctx context.Context, channel, threadTS, message string) (*slackclient.PostResult, error) {
+		PostThreadFunc: func(ctx context.Context, channel, threadTS, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			return &slackclient.PostResult{
 				Channel:  "C01234ABCDE",
 				TS:       "1234567890.654321",
@@ -283,7 +287,7 @@ func TestPostThreadHandler_NoThreadTS(t *testing.T) {
 // --- M10: Slack API エラーのMCPエラー変換 ---
 func TestPostMessageHandler_SlackAPIError(t *testing.T) {
 	mock := &slackclient.MockClient{
-		PostMessageFunc: func(ctx context.Context, channel, message string) (*slackclient.PostResult, error) {
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			return nil, apperr.New(apperr.CodeNotInChannel, "Botがチャンネルに参加していません", nil)
 		},
 	}
@@ -378,7 +382,7 @@ func TestAppendDisplayNameTag(t *testing.T) {
 func TestPostMessageHandler_WithDisplayName(t *testing.T) {
 	var capturedMessage string
 	mock := &slackclient.MockClient{
-		PostMessageFunc: func(ctx context.Context, channel, message string) (*slackclient.PostResult, error) {
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			capturedMessage = message
 			return &slackclient.PostResult{
 				Channel: "C01234ABCDE",
@@ -413,7 +417,7 @@ func TestPostMessageHandler_WithDisplayName(t *testing.T) {
 func TestPostMessageHandler_DisplayNameFromConfig(t *testing.T) {
 	var capturedMessage string
 	mock := &slackclient.MockClient{
-		PostMessageFunc: func(ctx context.Context, channel, message string) (*slackclient.PostResult, error) {
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			capturedMessage = message
 			return &slackclient.PostResult{
 				Channel: "C01234ABCDE",
@@ -444,7 +448,7 @@ func TestPostMessageHandler_DisplayNameFromConfig(t *testing.T) {
 func TestPostMessageHandler_DisplayNameParamOverridesConfig(t *testing.T) {
 	var capturedMessage string
 	mock := &slackclient.MockClient{
-		PostMessageFunc: func(ctx context.Context, channel, message string) (*slackclient.PostResult, error) {
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
 			capturedMessage = message
 			return &slackclient.PostResult{
 				Channel: "C01234ABCDE",
@@ -752,6 +756,638 @@ func TestAddReactionHandler_WithChannel(t *testing.T) {
 	}
 }
 
+// --- M06b: slack_get_history カーソル指定 ---
+func TestGetHistoryHandler_WithCursor(t *testing.T) {
+	var capturedCursor string
+	mock := &slackclient.MockClient{
+		GetHistoryFunc: func(ctx context.Context, channel string, opts slackclient.HistoryOptions) (*slackclient.HistoryResult, error) {
+			capturedCursor = opts.Cursor
+			return &slackclient.HistoryResult{
+				Channel:    "C01234ABCDE",
+				Messages:   []slackclient.HistoryMessage{},
+				HasMore:    true,
+				NextCursor: "page-3",
+			}, nil
+		},
+	}
+
+	handler := getHistoryHandler(mock, &config.Config{DefaultChannel: "general"})
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_get_history", map[string]any{
+		"cursor": "page-2",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if capturedCursor != "page-2" {
+		t.Errorf("capturedCursor = %q, want %q", capturedCursor, "page-2")
+	}
+	text := extractText(t, result)
+	if !strings.Contains(text, `"next_cursor":"page-3"`) {
+		t.Errorf("result = %q, want to contain next_cursor", text)
+	}
+}
+
+// --- M30: slack_get_thread 正常系 ---
+func TestGetThreadHandler_Success(t *testing.T) {
+	var captured slackclient.ThreadOptions
+	mock := &slackclient.MockClient{
+		GetThreadRepliesFunc: func(ctx context.Context, channel, threadTS string, opts slackclient.ThreadOptions) (*slackclient.ThreadResult, error) {
+			captured = opts
+			return &slackclient.ThreadResult{
+				Channel:  "C01234ABCDE",
+				ThreadTS: threadTS,
+				Messages: []slackclient.HistoryMessage{
+					{User: "U1", UserName: "alice", Text: "Parent", TS: threadTS},
+					{User: "U2", UserName: "bob", Text: "Reply", TS: "1234567890.222222", ThreadTS: threadTS},
+				},
+				HasMore:    true,
+				NextCursor: "next-page",
+				Count:      2,
+			}, nil
+		},
+	}
+
+	cfg := &config.Config{DefaultChannel: "general"}
+	handler := getThreadHandler(mock, cfg)
+
+	req := newTestCallToolRequest("slack_get_thread", map[string]any{
+		"thread_ts": "1234567890.111111",
+		"cursor":    "this-page",
+	})
+
+	result, err := handler(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := extractText(t, result)
+	if !strings.Contains(text, `"next_cursor":"next-page"`) {
+		t.Errorf("result = %q, want to contain next_cursor", text)
+	}
+	if !strings.Contains(text, "Reply") {
+		t.Errorf("result = %q, want to contain reply text", text)
+	}
+	if captured.Cursor != "this-page" || captured.Limit != 50 {
+		t.Errorf("opts = %+v, want cursor=this-page limit=50", captured)
+	}
+}
+
+// --- M31: slack_get_thread thread_ts 未指定 ---
+func TestGetThreadHandler_NoThreadTS(t *testing.T) {
+	handler := getThreadHandler(&slackclient.MockClient{}, &config.Config{DefaultChannel: "general"})
+
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_get_thread", map[string]any{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := extractText(t, result)
+	if !strings.Contains(text, "thread_not_found") {
+		t.Errorf("result = %q, want to contain thread_not_found", text)
+	}
+}
+
+// --- M32: slack_search_messages 正常系 ---
+func TestSearchMessagesHandler_Success(t *testing.T) {
+	var captured slackclient.SearchOptions
+	mock := &slackclient.MockClient{
+		SearchMessagesFunc: func(ctx context.Context, opts slackclient.SearchOptions) (*slackclient.SearchResult, error) {
+			captured = opts
+			return &slackclient.SearchResult{
+				Query: "deploy in:#general",
+				Messages: []slackclient.SearchMessage{
+					{
+						HistoryMessage: slackclient.HistoryMessage{User: "U1", UserName: "alice", Text: "deploy failed", TS: "1234567890.111111"},
+						Channel:        "C01234ABCDE",
+						ChannelName:    "general",
+					},
+				},
+				Total: 1, Page: 1, Pages: 1, Count: 1,
+			}, nil
+		},
+	}
+
+	handler := searchMessagesHandler(mock, &config.Config{DefaultChannel: "random"})
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_search_messages", map[string]any{
+		"query":   "deploy",
+		"channel": "general",
+		"sort":    "timestamp",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := extractText(t, result)
+	if !strings.Contains(text, `"channel_name":"general"`) || !strings.Contains(text, `"user_name":"alice"`) {
+		t.Errorf("result = %q, want flattened message with channel info", text)
+	}
+	if captured.Channel != "general" || captured.Sort != "timestamp" || captured.Count != 20 || captured.Page != 1 {
+		t.Errorf("opts = %+v, want channel=general sort=timestamp count=20 page=1", captured)
+	}
+}
+
+// --- M33: slack_search_messages クエリ未指定 / ユーザートークン未設定 ---
+func TestSearchMessagesHandler_Errors(t *testing.T) {
+	t.Run("no query", func(t *testing.T) {
+		handler := searchMessagesHandler(&slackclient.MockClient{}, &config.Config{})
+		result, err := handler(context.Background(), newTestCallToolRequest("slack_search_messages", map[string]any{"query": " "}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if text := extractText(t, result); !strings.Contains(text, "no_query") {
+			t.Errorf("result = %q, want to contain no_query", text)
+		}
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		handler := searchMessagesHandler(&slackclient.MockClient{}, &config.Config{})
+		for _, args := range []map[string]any{
+			{"query": "deploy", "sort": "newest"},
+			{"query": "deploy", "sort_dir": "up"},
+		} {
+			result, err := handler(context.Background(), newTestCallToolRequest("slack_search_messages", args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text := extractText(t, result); !strings.Contains(text, "invalid_sort") {
+				t.Errorf("%v: result = %q, want to contain invalid_sort", args, text)
+			}
+		}
+	})
+
+	t.Run("no user token", func(t *testing.T) {
+		mock := &slackclient.MockClient{
+			SearchMessagesFunc: func(ctx context.Context, opts slackclient.SearchOptions) (*slackclient.SearchResult, error) {
+				return nil, apperr.New(apperr.CodeUserTokenNotConfigured, "メッセージ検索にはユーザートークンが必要です", nil)
+			},
+		}
+		handler := searchMessagesHandler(mock, &config.Config{})
+		result, err := handler(context.Background(), newTestCallToolRequest("slack_search_messages", map[string]any{"query": "deploy"}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		text := extractText(t, result)
+		if !strings.Contains(text, "user_token_not_configured") || !strings.Contains(text, "SLACK_USER_TOKEN") {
+			t.Errorf("result = %q, want code and hint for missing user token", text)
+		}
+	})
+}
+
+// --- M34: slack_update_message 表示名タグの維持 ---
+func TestUpdateMessageHandler_KeepsDisplayNameTag(t *testing.T) {
+	var capturedTS, capturedMessage string
+	mock := &slackclient.MockClient{
+		UpdateMessageFunc: func(ctx context.Context, channel, timestamp, message string) (*slackclient.PostResult, error) {
+			capturedTS = timestamp
+			capturedMessage = message
+			return &slackclient.PostResult{Channel: "C01234ABCDE", TS: timestamp, Message: message}, nil
+		},
+	}
+
+	cfg := &config.Config{DefaultChannel: "general", DisplayName: "deploy-bot"}
+	handler := updateMessageHandler(mock, cfg)
+
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_update_message", map[string]any{
+		"timestamp": "1234567890.123456",
+		"message":   "Deploy finished",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text := extractText(t, result); !strings.Contains(text, `"ok":true`) {
+		t.Errorf("result = %q, want to contain ok:true", text)
+	}
+	if capturedTS != "1234567890.123456" {
+		t.Errorf("timestamp = %q, want %q", capturedTS, "1234567890.123456")
+	}
+	if capturedMessage != "Deploy finished\n#deploy-bot" {
+		t.Errorf("message = %q, want display-name tag appended", capturedMessage)
+	}
+}
+
+// --- M35: slack_update_message / slack_delete_message timestamp 未指定 ---
+func TestModifyMessageHandlers_NoTimestamp(t *testing.T) {
+	cfg := &config.Config{DefaultChannel: "general"}
+	tests := []struct {
+		name    string
+		handler server.ToolHandlerFunc
+		args    map[string]any
+	}{
+		{"update", updateMessageHandler(&slackclient.MockClient{}, cfg), map[string]any{"message": "hi"}},
+		{"delete", deleteMessageHandler(&slackclient.MockClient{}, cfg), map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.handler(context.Background(), newTestCallToolRequest("tool", tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text := extractText(t, result); !strings.Contains(text, "message_not_found") {
+				t.Errorf("result = %q, want to contain message_not_found", text)
+			}
+		})
+	}
+}
+
+// --- M36: slack_delete_message 正常系 ---
+func TestDeleteMessageHandler_Success(t *testing.T) {
+	var capturedChannel string
+	mock := &slackclient.MockClient{
+		DeleteMessageFunc: func(ctx context.Context, channel, timestamp string) (*slackclient.DeleteResult, error) {
+			capturedChannel = channel
+			return &slackclient.DeleteResult{Channel: "C09876ZZZZZ", ChannelName: channel, TS: timestamp}, nil
+		},
+	}
+
+	handler := deleteMessageHandler(mock, &config.Config{DefaultChannel: "general"})
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_delete_message", map[string]any{
+		"channel":   "random",
+		"timestamp": "1234567890.123456",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := extractText(t, result)
+	if !strings.Contains(text, `"ts":"1234567890.123456"`) {
+		t.Errorf("result = %q, want to contain ts", text)
+	}
+	if capturedChannel != "random" {
+		t.Errorf("channel = %q, want %q", capturedChannel, "random")
+	}
+}
+
+// --- M37: slack_post_message Block Kit ブロック指定 ---
+func TestPostMessageHandler_WithBlocks(t *testing.T) {
+	var captured slackclient.PostOptions
+	mock := &slackclient.MockClient{
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
+			captured = opts
+			return &slackclient.PostResult{Channel: "C01234ABCDE", TS: "1234567890.123456", Message: message}, nil
+		},
+	}
+	handler := postMessageHandler(mock, &config.Config{DefaultChannel: "general"})
+
+	tests := []struct {
+		name   string
+		blocks any
+	}{
+		{"array", []any{map[string]any{"type": "divider"}}},
+		{"json string", `[{"type":"divider"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captured = slackclient.PostOptions{}
+			result, err := handler(context.Background(), newTestCallToolRequest("slack_post_message", map[string]any{
+				"message": "Build passed",
+				"blocks":  tt.blocks,
+			}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text := extractText(t, result); !strings.Contains(text, `"ok":true`) {
+				t.Errorf("result = %q, want ok:true", text)
+			}
+			if captured.Blocks != `[{"type":"divider"}]` {
+				t.Errorf("Blocks = %q, want divider block JSON", captured.Blocks)
+			}
+		})
+	}
+}
+
+// --- M38: slack_post_thread 不正なブロック ---
+func TestPostThreadHandler_InvalidBlocks(t *testing.T) {
+	called := false
+	mock := &slackclient.MockClient{
+		PostThreadFunc: func(ctx context.Context, channel, threadTS, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
+			called = true
+			return &slackclient.PostResult{}, nil
+		},
+	}
+	handler := postThreadHandler(mock, &config.Config{DefaultChannel: "general"})
+
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_post_thread", map[string]any{
+		"thread_ts": "1234567890.123456",
+		"message":   "Details",
+		"blocks":    []any{map[string]any{"type": "carousel"}},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := extractText(t, result)
+	if !strings.Contains(text, "invalid_blocks") || !strings.Contains(text, "Hint:") {
+		t.Errorf("result = %q, want invalid_blocks with hint", text)
+	}
+	if called {
+		t.Error("PostThread should not be called with invalid blocks")
+	}
+}
+
+// --- M39: slack_upload_file インライン内容 ---
+func TestUploadFileHandler_Content(t *testing.T) {
+	var captured slackclient.UploadOptions
+	mock := &slackclient.MockClient{
+		UploadFileFunc: func(ctx context.Context, channel string, opts slackclient.UploadOptions) (*slackclient.UploadResult, error) {
+			captured = opts
+			return &slackclient.UploadResult{Channel: "C01234ABCDE", FileID: "F1", Filename: opts.Filename, Size: len(opts.Content)}, nil
+		},
+	}
+	handler := uploadFileHandler(mock, &config.Config{DefaultChannel: "general"})
+
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_upload_file", map[string]any{
+		"content":   "diff --git a b",
+		"thread_ts": "1234567890.123456",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text := extractText(t, result); !strings.Contains(text, `"file_id":"F1"`) {
+		t.Errorf("result = %q, want file_id", text)
+	}
+	if captured.Filename != "snippet.txt" || string(captured.Content) != "diff --git a b" || captured.ThreadTS != "1234567890.123456" {
+		t.Errorf("opts = %+v, want default filename, content and thread_ts", captured)
+	}
+}
+
+// --- M40: slack_upload_file ローカルパスの許可ディレクトリ制限 ---
+func TestUploadFileHandler_Path(t *testing.T) {
+	allowed := t.TempDir()
+	outside := t.TempDir()
+	writeFile := func(dir, name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	logPath := writeFile(allowed, "build.log", "ok")
+	secretPath := writeFile(outside, "secret.txt", "secret")
+	writeFile(allowed, "empty.txt", "")
+	if err := os.Symlink(secretPath, filepath.Join(allowed, "link.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	var captured slackclient.UploadOptions
+	mock := &slackclient.MockClient{
+		UploadFileFunc: func(ctx context.Context, channel string, opts slackclient.UploadOptions) (*slackclient.UploadResult, error) {
+			captured = opts
+			return &slackclient.UploadResult{FileID: "F1"}, nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		dirs     []string
+		args     map[string]any
+		wantText string
+	}{
+		{"allowed file", []string{allowed}, map[string]any{"path": logPath}, `"file_id":"F1"`},
+		{"no upload_dirs", nil, map[string]any{"path": logPath}, "path_not_allowed"},
+		{"outside allowed dir", []string{allowed}, map[string]any{"path": secretPath}, "path_not_allowed"},
+		{"dot-dot escape", []string{allowed}, map[string]any{"path": filepath.Join(allowed, "..", filepath.Base(outside), "secret.txt")}, "path_not_allowed"},
+		{"symlink escape", []string{allowed}, map[string]any{"path": filepath.Join(allowed, "link.txt")}, "path_not_allowed"},
+		{"missing file", []string{allowed}, map[string]any{"path": filepath.Join(allowed, "nope.log")}, "file_read_error"},
+		{"empty file", []string{allowed}, map[string]any{"path": filepath.Join(allowed, "empty.txt")}, "no_file_content"},
+		{"both content and path", []string{allowed}, map[string]any{"path": logPath, "content": "x"}, "no_file_content"},
+		{"neither", []string{allowed}, map[string]any{}, "no_file_content"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captured = slackclient.UploadOptions{}
+			handler := uploadFileHandler(mock, &config.Config{DefaultChannel: "general", UploadDirs: tt.dirs})
+			result, err := handler(context.Background(), newTestCallToolRequest("slack_upload_file", tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if text := extractText(t, result); !strings.Contains(text, tt.wantText) {
+				t.Errorf("result = %q, want to contain %q", text, tt.wantText)
+			}
+		})
+	}
+
+	// 許可されたファイルはファイル名がパスから補完される
+	handler := uploadFileHandler(mock, &config.Config{DefaultChannel: "general", UploadDirs: []string{allowed}})
+	if _, err := handler(context.Background(), newTestCallToolRequest("slack_upload_file", map[string]any{"path": logPath})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if captured.Filename != "build.log" || string(captured.Content) != "ok" {
+		t.Errorf("opts = %+v, want filename from path and file content", captured)
+	}
+}
+
+// --- M41: slack_list_channels 型指定の変換と結果 ---
+func TestListChannelsHandler(t *testing.T) {
+	var captured slackclient.ListChannelsOptions
+	mock := &slackclient.MockClient{
+		ListChannelsFunc: func(ctx context.Context, opts slackclient.ListChannelsOptions) (*slackclient.ListChannelsResult, error) {
+			captured = opts
+			return &slackclient.ListChannelsResult{
+				Channels: []slackclient.ChannelInfo{
+					{ID: "C01234ABCDE", Name: "proj-alpha", IsMember: true, NumMembers: 5},
+				},
+				HasMore:    true,
+				NextCursor: "next",
+				Count:      1,
+			}, nil
+		},
+	}
+
+	handler := listChannelsHandler(mock, &config.Config{})
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_list_channels", map[string]any{
+		"types":       "public, private,im",
+		"member_only": true,
+		"prefix":      "proj-",
+		"limit":       float64(20),
+		"cursor":      "abc",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(captured.Types, ",") != "public_channel,private_channel,im" {
+		t.Errorf("Types = %v, want mapped conversation types", captured.Types)
+	}
+	if !captured.MemberOnly || captured.Prefix != "proj-" || captured.Limit != 20 || captured.Cursor != "abc" || captured.IncludeArchived {
+		t.Errorf("opts = %+v, want member_only/prefix/limit/cursor passed through", captured)
+	}
+	text := extractText(t, result)
+	for _, want := range []string{`"name":"proj-alpha"`, `"is_member":true`, `"has_more":true`, `"next_cursor":"next"`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result = %q, want to contain %q", text, want)
+		}
+	}
+}
+
+// --- M42: slack_send_dm ユーザー名に @ を補ってDMの宛先として投稿 ---
+func TestSendDMHandler(t *testing.T) {
+	var gotChannel string
+	mock := &slackclient.MockClient{
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
+			gotChannel = channel
+			return &slackclient.PostResult{Channel: "D01234ABCDE", TS: "1234567890.123456", Message: message}, nil
+		},
+	}
+
+	handler := sendDMHandler(mock, &config.Config{})
+	for _, tt := range []struct{ user, want string }{
+		{"alice", "@alice"},
+		{"@alice", "@alice"},
+		{"alice@example.com", "alice@example.com"},
+		{"U01234ABCDE", "U01234ABCDE"},
+	} {
+		result, err := handler(context.Background(), newTestCallToolRequest("slack_send_dm", map[string]any{
+			"user":    tt.user,
+			"message": "hello",
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("user=%q: unexpected error result: %s", tt.user, extractText(t, result))
+		}
+		if gotChannel != tt.want {
+			t.Errorf("user=%q: channel = %q, want %q", tt.user, gotChannel, tt.want)
+		}
+		if !strings.Contains(extractText(t, result), `"channel":"D01234ABCDE"`) {
+			t.Errorf("result should contain DM channel ID")
+		}
+	}
+
+	result, _ := handler(context.Background(), newTestCallToolRequest("slack_send_dm", map[string]any{
+		"message": "hello",
+	}))
+	if !result.IsError || !strings.Contains(extractText(t, result), "user_not_found") {
+		t.Errorf("missing user should return user_not_found error")
+	}
+}
+
+// --- M43: slack_schedule_message 相対時間の解釈とデフォルトチャンネル ---
+func TestScheduleMessageHandler(t *testing.T) {
+	var gotChannel string
+	var gotPostAt time.Time
+	mock := &slackclient.MockClient{
+		ScheduleMessageFunc: func(ctx context.Context, channel, message string, postAt time.Time, opts slackclient.PostOptions) (*slackclient.ScheduleResult, error) {
+			gotChannel = channel
+			gotPostAt = postAt
+			return &slackclient.ScheduleResult{Channel: "C01234ABCDE", ScheduledMessageID: "Q1", PostAt: postAt.Unix(), Message: message}, nil
+		},
+	}
+
+	handler := scheduleMessageHandler(mock, &config.Config{DefaultChannel: "general"})
+	before := time.Now()
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_schedule_message", map[string]any{
+		"message": "deploy window opens",
+		"post_at": "in 2h",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %s", extractText(t, result))
+	}
+	if gotChannel != "general" {
+		t.Errorf("channel = %q, want default channel", gotChannel)
+	}
+	if d := gotPostAt.Sub(before); d < 2*time.Hour || d > 2*time.Hour+time.Minute {
+		t.Errorf("post_at = %v, want about 2h from now", gotPostAt)
+	}
+	if !strings.Contains(extractText(t, result), `"scheduled_message_id":"Q1"`) {
+		t.Errorf("result should contain scheduled_message_id")
+	}
+
+	// 解釈できない post_at はクライアントを呼ばずにエラー
+	gotChannel = ""
+	result, _ = handler(context.Background(), newTestCallToolRequest("slack_schedule_message", map[string]any{
+		"message": "hi",
+		"post_at": "tomorrow morning",
+	}))
+	if !result.IsError || !strings.Contains(extractText(t, result), "invalid_post_at") {
+		t.Errorf("invalid post_at should return invalid_post_at error")
+	}
+	if gotChannel != "" {
+		t.Errorf("client should not be called for invalid post_at")
+	}
+}
+
+// --- M44: slack_list_scheduled_messages はデフォルトチャンネルにフォールバックしない ---
+func TestListScheduledMessagesHandler(t *testing.T) {
+	var captured slackclient.ListScheduledOptions
+	mock := &slackclient.MockClient{
+		ListScheduledFunc: func(ctx context.Context, opts slackclient.ListScheduledOptions) (*slackclient.ListScheduledResult, error) {
+			captured = opts
+			return &slackclient.ListScheduledResult{
+				Messages: []slackclient.ScheduledMessage{{ID: "Q1", Channel: "C01234ABCDE", PostAt: 1767225600, Text: "hi"}},
+				Count:    1,
+			}, nil
+		},
+	}
+
+	handler := listScheduledMessagesHandler(mock, &config.Config{DefaultChannel: "general"})
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_list_scheduled_messages", map[string]any{
+		"limit": float64(10),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if captured.Channel != "" || captured.Limit != 10 {
+		t.Errorf("opts = %+v, want all channels with limit 10", captured)
+	}
+	if !strings.Contains(extractText(t, result), `"id":"Q1"`) {
+		t.Errorf("result should contain scheduled message")
+	}
+}
+
+// --- M45: slack_delete_scheduled_message ID 未指定 ---
+func TestDeleteScheduledMessageHandler_NoID(t *testing.T) {
+	handler := deleteScheduledMessageHandler(&slackclient.MockClient{}, &config.Config{DefaultChannel: "general"})
+	result, err := handler(context.Background(), newTestCallToolRequest("slack_delete_scheduled_message", map[string]any{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError || !strings.Contains(extractText(t, result), "scheduled_message_not_found") {
+		t.Errorf("missing scheduled_message_id should return scheduled_message_not_found error")
+	}
+}
+
+// --- M48: slack_post_message / slack_post_thread は idempotency_key を渡し、再送結果に replayed を含める ---
+func TestPostHandlers_IdempotencyKey(t *testing.T) {
+	var keys []string
+	mock := &slackclient.MockClient{
+		PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
+			keys = append(keys, opts.IdempotencyKey)
+			return &slackclient.PostResult{Channel: "C01234ABCDE", TS: "1234567890.123456", Replayed: opts.IdempotencyKey != ""}, nil
+		},
+		PostThreadFunc: func(ctx context.Context, channel, threadTS, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
+			keys = append(keys, opts.IdempotencyKey)
+			return &slackclient.PostResult{Channel: "C01234ABCDE", TS: "1234567890.654321", ThreadTS: threadTS}, nil
+		},
+	}
+	cfg := &config.Config{DefaultChannel: "general"}
+
+	result, err := postMessageHandler(mock, cfg)(context.Background(), newTestCallToolRequest("slack_post_message", map[string]any{
+		"message":         "deployed",
+		"idempotency_key": "deploy-42",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(extractText(t, result), `"replayed":true`) {
+		t.Errorf("replayed result should contain replayed: %s", extractText(t, result))
+	}
+
+	result, err = postThreadHandler(mock, cfg)(context.Background(), newTestCallToolRequest("slack_post_thread", map[string]any{
+		"thread_ts":       "1234567890.123456",
+		"message":         "details",
+		"idempotency_key": "reply-42",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(extractText(t, result), "replayed") {
+		t.Errorf("first post should not contain replayed: %s", extractText(t, result))
+	}
+
+	if strings.Join(keys, ",") != "deploy-42,reply-42" {
+		t.Errorf("idempotency keys = %v, want [deploy-42 reply-42]", keys)
+	}
+}
+
 // --- ヘルパー ---
 
 func extractText(t *testing.T, result *mcp.CallToolResult) string {
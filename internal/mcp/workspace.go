@@ -0,0 +1,104 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// workspaceNames は MCP Server が扱うワークスペースのプロファイル名を返す。
+// default_profile が未設定の場合はトップレベルの設定（空文字）も含める。
+func workspaceNames(cfg *config.Config) []string {
+	names := cfg.ProfileNames()
+	if cfg.DefaultProfile == "" {
+		names = append([]string{""}, names...)
+	}
+	return names
+}
+
+// workspaces はワークスペース（プロファイル）ごとの設定と Slack クライアント。
+// ツールの workspace パラメータで呼び出し先を切り替える。
+type workspaces struct {
+	defaultName string   // workspace 未指定時のプロファイル名（空文字はトップレベルの設定）
+	names       []string // workspace に指定できるプロファイル名（名前順）
+	configs     map[string]*config.Config
+	clients     map[string]slackclient.SlackClient
+}
+
+// newWorkspaces は clients（キーはプロファイル名）と対応する設定から workspaces を作成する。
+func newWorkspaces(cfg *config.Config, clients map[string]slackclient.SlackClient) *workspaces {
+	w := &workspaces{
+		defaultName: cfg.DefaultProfile,
+		configs:     make(map[string]*config.Config, len(clients)),
+		clients:     clients,
+	}
+	for name := range clients {
+		wcfg, err := cfg.Profile(name)
+		if err != nil {
+			wcfg = cfg
+		}
+		w.configs[name] = wcfg
+		if name != "" {
+			w.names = append(w.names, name)
+		}
+	}
+	sort.Strings(w.names)
+	return w
+}
+
+// tool はプロファイルが設定されている場合にツールへ workspace パラメータを追加する。
+func (w *workspaces) tool(t mcp.Tool) mcp.Tool {
+	if len(w.names) == 0 {
+		return t
+	}
+
+	desc := fmt.Sprintf("Slack workspace (config profile) to use: %s. ", quoteNames(w.names))
+	if w.defaultName != "" {
+		desc += fmt.Sprintf("If omitted, uses the default workspace '%s'.", w.defaultName)
+	} else {
+		desc += "If omitted, uses the default workspace from the top-level config."
+	}
+	mcp.WithString("workspace", mcp.Description(desc), mcp.Enum(w.names...))(&t)
+	return t
+}
+
+// handler はワークスペースごとにハンドラを作成し、workspace パラメータで振り分けるハンドラを返す。
+func (w *workspaces) handler(newHandler func(slackclient.SlackClient, *config.Config) server.ToolHandlerFunc) server.ToolHandlerFunc {
+	handlers := make(map[string]server.ToolHandlerFunc, len(w.clients))
+	for name, client := range w.clients {
+		handlers[name] = newHandler(client, w.configs[name])
+	}
+
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := request.GetString("workspace", "")
+		if name == "" {
+			name = w.defaultName
+		}
+		h, ok := handlers[name]
+		if !ok {
+			configured := "none"
+			if len(w.names) > 0 {
+				configured = strings.Join(w.names, ", ")
+			}
+			return handleAppError(apperr.New(apperr.CodeProfileNotFound,
+				fmt.Sprintf("ワークスペースが見つかりません: %s（設定済み: %s）", name, configured), nil))
+		}
+		return h(ctx, request)
+	}
+}
+
+// quoteNames は名前を 'a', 'b' の形式で連結する。
+func quoteNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = "'" + n + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/config"
+	"github.com/kai-kou/slack-fast-mcp/internal/logging"
+	slackclient "github.com/kai-kou/slack-fast-mcp/internal/slack"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// --- M49: workspace パラメータでプロファイルごとのクライアント・設定に振り分ける ---
+func TestServer_Workspaces(t *testing.T) {
+	cfg := &config.Config{
+		Token:          "xoxb-internal",
+		DefaultChannel: "general",
+		Profiles: map[string]*config.Config{
+			"customer": {Token: "xoxb-customer", DefaultChannel: "shared-support"},
+		},
+	}
+
+	var posted []string
+	newMock := func(workspace string) *slackclient.MockClient {
+		return &slackclient.MockClient{
+			PostMessageFunc: func(ctx context.Context, channel, message string, opts slackclient.PostOptions) (*slackclient.PostResult, error) {
+				posted = append(posted, workspace+"/"+channel)
+				return &slackclient.PostResult{Channel: "C01234ABCDE", ChannelName: channel}, nil
+			},
+		}
+	}
+	s := newServer(cfg, map[string]slackclient.SlackClient{
+		"":         newMock("internal"),
+		"customer": newMock("customer"),
+	}, logging.Discard())
+
+	call := func(args map[string]any) mcp.CallToolResult {
+		t.Helper()
+		req, _ := json.Marshal(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  "tools/call",
+			"params":  map[string]any{"name": "slack_post_message", "arguments": args},
+		})
+		resp, ok := s.HandleMessage(context.Background(), req).(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("response is not JSONRPCResponse")
+		}
+		return resp.Result.(mcp.CallToolResult)
+	}
+
+	call(map[string]any{"message": "hi"})
+	call(map[string]any{"message": "hi", "workspace": "customer"})
+	if strings.Join(posted, ",") != "internal/general,customer/shared-support" {
+		t.Errorf("posted = %v, want each workspace's client and default channel", posted)
+	}
+
+	result := call(map[string]any{"message": "hi", "workspace": "partner"})
+	if text := result.Content[0].(mcp.TextContent).Text; !result.IsError || !strings.Contains(text, "profile_not_found") || !strings.Contains(text, "customer") {
+		t.Errorf("unknown workspace result = %q, want profile_not_found listing profiles", text)
+	}
+
+	// プロファイルがある場合は全ツールに workspace パラメータを追加する
+	for name, tool := range s.ListTools() {
+		prop, ok := tool.Tool.InputSchema.Properties["workspace"].(map[string]any)
+		if !ok {
+			t.Errorf("%s: missing workspace parameter", name)
+			continue
+		}
+		if enum, _ := prop["enum"].([]string); len(enum) != 1 || enum[0] != "customer" {
+			t.Errorf("%s: workspace enum = %v, want [customer]", name, prop["enum"])
+		}
+	}
+
+	// プロファイルがない場合は追加しない
+	single := NewServerWithClient(&config.Config{}, &slackclient.MockClient{})
+	for name, tool := range single.ListTools() {
+		if _, ok := tool.Tool.InputSchema.Properties["workspace"]; ok {
+			t.Errorf("%s: workspace parameter without profiles", name)
+		}
+	}
+}
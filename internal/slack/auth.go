@@ -0,0 +1,123 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// scopeHeader は Slack Web API の応答でトークンに付与されたスコープを返すヘッダ。
+const scopeHeader = "X-OAuth-Scopes"
+
+// scopeRecorder は Slack API の応答ヘッダからトークンに付与されたスコープを記録する http.RoundTripper。
+// slack-go は応答ヘッダを返さないため、HTTP クライアントの層で取得する。
+type scopeRecorder struct {
+	base http.RoundTripper
+
+	mu     sync.Mutex
+	scopes []string
+	seen   bool
+}
+
+func (r *scopeRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := resp.Header[http.CanonicalHeaderKey(scopeHeader)]; ok && len(v) > 0 {
+		r.mu.Lock()
+		r.scopes = parseScopes(v[0])
+		r.seen = true
+		r.mu.Unlock()
+	}
+	return resp, nil
+}
+
+// granted は最後に受け取った応答のスコープを返す。r が nil、またはヘッダを受け取っていない場合は nil。
+func (r *scopeRecorder) granted() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.seen {
+		return nil
+	}
+	return append([]string{}, r.scopes...)
+}
+
+// parseScopes はカンマ区切りのスコープ一覧（例: "chat:write,channels:read"）を分割する。
+func parseScopes(s string) []string {
+	scopes := []string{}
+	for _, scope := range strings.Split(s, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// AuthTest はトークンを検証し（auth.test）、ワークスペース・Bot の情報と付与されたスコープを返す。
+// ユーザートークンが設定されている場合はそのスコープも確認する（失敗しても Bot の結果は返す）。
+func (c *Client) AuthTest(ctx context.Context) (*AuthInfo, error) {
+	var resp *slackapi.AuthTestResponse
+	err := c.withRetry(ctx, "auth.test", func() error {
+		var e error
+		resp, e = c.api.AuthTestContext(ctx)
+		return e
+	})
+	if err != nil {
+		return nil, classifySlackError(err)
+	}
+
+	info := &AuthInfo{
+		URL:    resp.URL,
+		Team:   resp.Team,
+		TeamID: resp.TeamID,
+		User:   resp.User,
+		UserID: resp.UserID,
+		BotID:  resp.BotID,
+		Scopes: c.scopes.granted(),
+	}
+	if c.userAPI != nil {
+		err := c.withRetry(ctx, "auth.test", func() error {
+			_, e := c.userAPI.AuthTestContext(ctx)
+			return e
+		})
+		if err == nil {
+			info.UserScopes = c.userScopes.granted()
+		}
+	}
+	return info, nil
+}
+
+// ChannelMembership は Bot がチャンネルに参加しているかどうかを返す（conversations.info）。
+func (c *Client) ChannelMembership(ctx context.Context, channel string) (*MembershipResult, error) {
+	channelID, err := c.resolveChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	var info *slackapi.Channel
+	err = c.withRetry(ctx, "conversations.info", func() error {
+		var e error
+		info, e = c.api.GetConversationInfoContext(ctx, &slackapi.GetConversationInfoInput{ChannelID: channelID})
+		return e
+	})
+	if err != nil {
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
+	}
+
+	name := info.Name
+	if name == "" {
+		name = c.getChannelName(channel, channelID)
+	}
+	return &MembershipResult{
+		Channel:     channelID,
+		ChannelName: name,
+		IsMember:    info.IsMember,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackapi "github.com/slack-go/slack"
+)
+
+// maxBlocks は1メッセージあたりのブロック数の上限（Slack API の制限）。
+const maxBlocks = 50
+
+// messageOptions は本文とオプションから chat.postMessage 用の MsgOption を組み立てる。
+// Blocks 指定時も message はフォールバックテキスト（通知・検索用）として送信する。
+func messageOptions(message string, opts PostOptions) ([]slackapi.MsgOption, error) {
+	msgOpts := []slackapi.MsgOption{slackapi.MsgOptionText(message, false)}
+	if strings.TrimSpace(opts.Blocks) == "" {
+		return msgOpts, nil
+	}
+
+	blocks, err := ParseBlocks(opts.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	return append(msgOpts, slackapi.MsgOptionBlocks(blocks...)), nil
+}
+
+// ParseBlocks は Block Kit ブロックの JSON 配列を検証・パースする。
+// slack-go が対応していないブロック種別や空配列は invalid_blocks エラーにする。
+func ParseBlocks(raw string) ([]slackapi.Block, error) {
+	var blocks slackapi.Blocks
+	if err := json.Unmarshal([]byte(raw), &blocks); err != nil {
+		return nil, apperr.New(apperr.CodeInvalidBlocks,
+			"blocks は Block Kit ブロックの JSON 配列で指定してください", err)
+	}
+
+	if len(blocks.BlockSet) == 0 {
+		return nil, apperr.New(apperr.CodeInvalidBlocks, "blocks が空です", nil)
+	}
+	if len(blocks.BlockSet) > maxBlocks {
+		return nil, apperr.New(apperr.CodeInvalidBlocks,
+			fmt.Sprintf("blocks は最大%d個までです（%d個指定）", maxBlocks, len(blocks.BlockSet)), nil)
+	}
+
+	for i, b := range blocks.BlockSet {
+		if u, ok := b.(*slackapi.UnknownBlock); ok {
+			return nil, apperr.New(apperr.CodeInvalidBlocks,
+				fmt.Sprintf("blocks[%d] のブロック種別 %q には対応していません", i, u.Type), nil)
+		}
+	}
+
+	return blocks.BlockSet, nil
+}
@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// --- B01: Block Kit JSON の検証 ---
+func TestParseBlocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    int
+		wantErr string
+	}{
+		{"section and divider", `[{"type":"section","text":{"type":"mrkdwn","text":"*hi*"}},{"type":"divider"}]`, 2, ""},
+		{"header", `[{"type":"header","text":{"type":"plain_text","text":"CI"}}]`, 1, ""},
+		{"not an array", `{"type":"section"}`, 0, "invalid_blocks"},
+		{"malformed json", `[{"type":`, 0, "invalid_blocks"},
+		{"empty array", `[]`, 0, "invalid_blocks"},
+		{"unsupported type", `[{"type":"carousel"}]`, 0, `"carousel"`},
+		{"too many blocks", "[" + strings.Repeat(`{"type":"divider"},`, 50) + `{"type":"divider"}]`, 0, "最大50個"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks, err := ParseBlocks(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(blocks) != tt.want {
+				t.Errorf("len(blocks) = %d, want %d", len(blocks), tt.want)
+			}
+		})
+	}
+}
+
+// --- B02: PostMessage でブロックとフォールバックテキストを送信 ---
+func TestClient_PostMessage_WithBlocks(t *testing.T) {
+	var gotText, gotBlocks string
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.postMessage": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			gotText = r.FormValue("text")
+			gotBlocks = r.FormValue("blocks")
+			jsonResponse(w, map[string]any{"ok": true, "channel": "C01234ABCDE", "ts": "1234567890.123456"})
+		},
+		"/chat.getPermalink": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "permalink": "https://test.slack.com/archives/C01234ABCDE/p1234567890123456"})
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	_, err := client.PostThread(context.Background(), "C01234ABCDE", "1234567890.000001", "Build passed", PostOptions{
+		Blocks: `[{"type":"section","text":{"type":"mrkdwn","text":"*Build passed*"}}]`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotText != "Build passed" {
+		t.Errorf("text = %q, want fallback text", gotText)
+	}
+	if !strings.Contains(gotBlocks, `"type":"section"`) || !strings.Contains(gotBlocks, "*Build passed*") {
+		t.Errorf("blocks = %q, want section block", gotBlocks)
+	}
+}
+
+// --- B03: 不正なブロックは API を呼ばずにエラー / API の invalid_blocks ---
+func TestClient_PostMessage_InvalidBlocks(t *testing.T) {
+	called := false
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.postMessage": func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			jsonResponse(w, map[string]any{"ok": false, "error": "invalid_blocks"})
+		},
+	})
+	client := NewClientWithAPI(api)
+
+	_, err := client.PostMessage(context.Background(), "C01234ABCDE", "hi", PostOptions{Blocks: `[{"type":"nope"}]`})
+	if err == nil || !strings.Contains(err.Error(), "invalid_blocks") {
+		t.Errorf("error = %v, want invalid_blocks", err)
+	}
+	if called {
+		t.Error("chat.postMessage should not be called for locally invalid blocks")
+	}
+
+	// ローカル検証を通っても Slack 側で拒否された場合
+	_, err = client.PostMessage(context.Background(), "C01234ABCDE", "hi", PostOptions{Blocks: `[{"type":"section"}]`})
+	if err == nil || !strings.Contains(err.Error(), "invalid_blocks") {
+		t.Errorf("error = %v, want invalid_blocks", err)
+	}
+}
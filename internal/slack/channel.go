@@ -2,8 +2,10 @@ package slack
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
 	slackapi "github.com/slack-go/slack"
@@ -18,33 +20,89 @@ func IsChannelID(s string) bool {
 	return channelIDPattern.MatchString(s)
 }
 
+// channelIndexRefreshInterval は完全なチャンネル索引を再構築せずに「存在しない」と判断する期間。
+// 存在しない名前の連続した問い合わせで毎回全ページを走査しないようにする。
+// これより古い索引に見つからない名前は、新規作成の可能性があるため索引を再構築して確認する。
+const channelIndexRefreshInterval = time.Minute
+
 // resolveChannel はチャンネル名をチャンネルIDに変換する。
 // 1. チャンネルID形式ならそのまま返す
-// 2. "#" 付きなら除去してチャンネル名として検索
-// 3. それ以外は conversations.list で検索
+// 2. @ユーザー名・メールアドレス・ユーザーIDならDMチャンネルを開く
+// 3. "#" 付きなら除去してチャンネル名として検索
+// 4. メモリ → ディスクのキャッシュを確認
+// 5. なければ conversations.list で全チャンネルの索引を構築して検索
 func (c *Client) resolveChannel(ctx context.Context, channel string) (string, error) {
 	// チャンネルIDならそのまま返す
 	if IsChannelID(channel) {
 		return channel, nil
 	}
 
+	// DMの宛先ならDMチャンネルIDに変換
+	if IsDMTarget(channel) {
+		return c.resolveDMChannel(ctx, channel)
+	}
+
 	// "#" プレフィックスを除去
 	name := strings.TrimPrefix(channel, "#")
 
 	// キャッシュを確認
-	if id, ok := c.channelCache[name]; ok {
+	if id, ok := c.cachedChannel(name); ok {
 		return id, nil
 	}
 
-	// conversations.list で検索
-	id, err := c.findChannelByName(ctx, name)
-	if err != nil {
-		return "", err
+	// ディスクキャッシュを確認（CLI の起動ごとの conversations.list 走査を避ける）
+	if id, ok := c.cachedChannelID(ctx, name); ok {
+		c.channelMu.Lock()
+		c.channelCache[name] = id
+		c.channelMu.Unlock()
+		return id, nil
 	}
 
-	// キャッシュに保存
-	c.channelCache[name] = id
-	return id, nil
+	// 直前に構築した完全な索引になければ API を呼ばずに見つからないと判断する
+	c.channelMu.Lock()
+	fresh := c.channelIndexComplete && time.Since(c.channelIndexAt) < channelIndexRefreshInterval
+	c.channelMu.Unlock()
+	if !fresh {
+		if _, err := c.WarmChannelIndex(ctx); err != nil {
+			// 走査が途中で失敗・期限切れになっても、読み終えたページに見つかっていれば使う
+			if id, ok := c.cachedChannel(name); ok {
+				return id, nil
+			}
+			return "", err
+		}
+	}
+
+	// 索引の構築は並行する別の呼び出しで完了している場合もあるため、判定の前に再確認する
+	if id, ok := c.cachedChannel(name); ok {
+		return id, nil
+	}
+	return "", c.channelNotFound(name)
+}
+
+// cachedChannel はメモリキャッシュからチャンネルIDを取得する。
+func (c *Client) cachedChannel(name string) (string, bool) {
+	c.channelMu.Lock()
+	defer c.channelMu.Unlock()
+	id, ok := c.channelCache[name]
+	return id, ok
+}
+
+// channelNotFound は索引済みのチャンネル名から修正候補を付けた channel_not_found エラーを返す。
+func (c *Client) channelNotFound(name string) error {
+	c.channelMu.Lock()
+	names := make([]string, 0, len(c.channelCache))
+	for n := range c.channelCache {
+		names = append(names, n)
+	}
+	complete := c.channelIndexComplete
+	c.channelMu.Unlock()
+
+	msg := "指定されたチャンネルが見つかりません: " + name
+	if !complete && c.maxScanPages > 0 {
+		msg += fmt.Sprintf("（channel_scan_max_pages=%d の範囲のみ検索）", c.maxScanPages)
+	}
+	return apperr.New(apperr.CodeChannelNotFound, msg, nil).
+		WithSuggestions(suggestChannelNames(name, names, maxChannelSuggestions))
 }
 
 // conversationTypes はチャンネル検索対象の型リスト。
@@ -55,55 +113,208 @@ var defaultConversationTypes = [][]string{
 	{"public_channel"},                    // フォールバック（channels:read のみで動作）
 }
 
-// findChannelByName は conversations.list API でチャンネル名からIDを検索する。
-// ページネーション: cursor ベース、最大5ページ（1000チャンネル）で打ち切り。
-func (c *Client) findChannelByName(ctx context.Context, name string) (string, error) {
+// WarmChannelIndex は conversations.list を全ページ走査してチャンネル名 → ID の索引を構築する。
+// 以降の名前解決はこの索引（メモリ・ディスクキャッシュ）を使う。索引に登録したチャンネル数を返す。
+// ページ数の上限は WithChannelScanLimit で設定でき、未設定時は ctx がキャンセルされるまで全ページを読む。
+// 同時に呼び出された場合は走査を1回にまとめ、全員が同じ結果を受け取る（走査には最初の呼び出しの ctx を使い、
+// 後から加わった呼び出しは自身の ctx がキャンセル・期限切れになると待機をやめる）。
+func (c *Client) WarmChannelIndex(ctx context.Context) (int, error) {
+	count, err, _ := c.indexFlight.do(ctx, "index", func() (int, error) {
+		return c.buildChannelIndex(ctx)
+	})
+	return count, err
+}
+
+// buildChannelIndex はチャンネル索引を構築する（WarmChannelIndex の本体）。
+func (c *Client) buildChannelIndex(ctx context.Context) (int, error) {
+	var lastErr error
 	for _, types := range defaultConversationTypes {
-		id, err := c.findChannelByNameWithTypes(ctx, name, types)
-		if err == nil {
-			return id, nil
+		channels, complete, err := c.scanChannels(ctx, types)
+		if err != nil {
+			// missing_scope エラーの場合はフォールバック（private_channel を外して再試行）
+			if appErr, ok := err.(*apperr.AppError); ok && appErr.Code == apperr.CodeMissingScope {
+				lastErr = err
+				continue
+			}
+			return 0, err
 		}
 
-		// missing_scope エラーの場合はフォールバック（private_channel を外して再試行）
-		if appErr, ok := err.(*apperr.AppError); ok && appErr.Code == apperr.CodeMissingScope {
+		c.channelMu.Lock()
+		c.channelIndexComplete = complete
+		c.channelIndexAt = time.Now()
+		c.channelMu.Unlock()
+		return len(channels), nil
+	}
+	return 0, lastErr
+}
+
+// scanChannels は指定された会話タイプのチャンネルを走査し、名前 → ID を返す。
+// 読み終えたページは都度キャッシュに保存する（途中で失敗・期限切れになっても次回の名前解決に使える）。
+// 最後のページまで読み切った場合は complete が true になる。
+func (c *Client) scanChannels(ctx context.Context, types []string) (map[string]string, bool, error) {
+	params := &slackapi.GetConversationsParameters{
+		Types:           types,
+		Limit:           200,
+		ExcludeArchived: true,
+	}
+
+	channels := make(map[string]string)
+	for page := 0; c.maxScanPages <= 0 || page < c.maxScanPages; page++ {
+		var chs []slackapi.Channel
+		var nextCursor string
+		err := c.withRetry(ctx, "conversations.list", func() error {
+			var e error
+			chs, nextCursor, e = c.api.GetConversationsContext(ctx, params)
+			return e
+		})
+		if err != nil {
+			return nil, false, classifySlackError(err)
+		}
+
+		pageChannels := make(map[string]string, len(chs))
+		for _, ch := range chs {
+			if ch.Name != "" {
+				channels[ch.Name] = ch.ID
+				pageChannels[ch.Name] = ch.ID
+			}
+		}
+		c.storeChannelIDs(ctx, pageChannels)
+
+		if nextCursor == "" {
+			return channels, true, nil
+		}
+		params.Cursor = nextCursor
+	}
+	return channels, false, nil
+}
+
+// conversationTypeAliases は会話タイプの短縮名。
+var conversationTypeAliases = map[string]string{
+	"public":  "public_channel",
+	"private": "private_channel",
+}
+
+// ParseConversationTypes はカンマ区切りの会話タイプ指定（例: "public,private,im"）を
+// conversations.list の types に変換する。空文字の場合は nil（デフォルト）を返す。
+func ParseConversationTypes(s string) []string {
+	var types []string
+	for _, t := range strings.Split(s, ",") {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" {
 			continue
 		}
+		if alias, ok := conversationTypeAliases[t]; ok {
+			t = alias
+		}
+		types = append(types, t)
+	}
+	return types
+}
+
+// listChannelsMaxScanPages は ListChannels 1回あたりに conversations.list を呼び出す最大ページ数。
+// 絞り込み条件に一致するチャンネルが少ない場合でも応答時間を抑えるため、超過時は next_cursor を返して打ち切る。
+const listChannelsMaxScanPages = 10
 
-		// それ以外のエラー（channel_not_found 等）はそのまま返す
-		return "", err
+// ListChannels はワークスペースのチャンネル一覧を取得する（conversations.list）。
+// 名前の前方一致・参加中のみの絞り込みはページ取得後に行うため、一致件数が Limit に達するまで
+// 複数ページを読み進める。読み込んだページ内の一致チャンネルはすべて返す（Limit を超える場合がある）。
+func (c *Client) ListChannels(ctx context.Context, opts ListChannelsOptions) (*ListChannelsResult, error) {
+	// limit のバリデーション
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 100
 	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	typeSets := [][]string{opts.Types}
+	if len(opts.Types) == 0 {
+		typeSets = defaultConversationTypes
+	}
+
+	var lastErr error
+	for _, types := range typeSets {
+		result, err := c.listChannelsWithTypes(ctx, types, limit, opts)
+		if err == nil {
+			return result, nil
+		}
 
-	return "", apperr.New(apperr.CodeChannelNotFound,
-		"指定されたチャンネルが見つかりません: "+name, nil)
+		// 型未指定時のみ missing_scope でフォールバック（private_channel を外して再試行）
+		if appErr, ok := err.(*apperr.AppError); ok && appErr.Code == apperr.CodeMissingScope && len(opts.Types) == 0 {
+			lastErr = err
+			continue
+		}
+		return nil, err
+	}
+	return nil, lastErr
 }
 
-// findChannelByNameWithTypes は指定された会話タイプでチャンネル名からIDを検索する。
-func (c *Client) findChannelByNameWithTypes(ctx context.Context, name string, types []string) (string, error) {
+// listChannelsWithTypes は指定された会話タイプでチャンネル一覧を取得する。
+func (c *Client) listChannelsWithTypes(ctx context.Context, types []string, limit int, opts ListChannelsOptions) (*ListChannelsResult, error) {
+	filtered := opts.Prefix != "" || opts.MemberOnly
+	pageSize := limit
+	if filtered {
+		// 絞り込み時は1ページで多く読み込む
+		pageSize = 200
+	}
+
 	params := &slackapi.GetConversationsParameters{
 		Types:           types,
-		Limit:           200,
-		ExcludeArchived: true,
+		Limit:           pageSize,
+		ExcludeArchived: !opts.IncludeArchived,
+		Cursor:          opts.Cursor,
 	}
+	prefix := strings.ToLower(strings.TrimPrefix(opts.Prefix, "#"))
 
-	maxPages := 5
-	for page := 0; page < maxPages; page++ {
-		channels, nextCursor, err := c.api.GetConversationsContext(ctx, params)
+	channels := []ChannelInfo{}
+	nextCursor := ""
+	seen := make(map[string]string)
+	defer c.storeChannelIDs(ctx, seen)
+	for page := 0; page < listChannelsMaxScanPages; page++ {
+		var chs []slackapi.Channel
+		err := c.withRetry(ctx, "conversations.list", func() error {
+			var e error
+			chs, nextCursor, e = c.api.GetConversationsContext(ctx, params)
+			return e
+		})
 		if err != nil {
-			return "", classifySlackError(err)
+			return nil, classifySlackError(err)
 		}
 
-		for _, ch := range channels {
-			if ch.Name == name {
-				return ch.ID, nil
+		for _, ch := range chs {
+			// 取得したチャンネルは名前解決キャッシュにも保存する
+			if ch.Name != "" {
+				seen[ch.Name] = ch.ID
+			}
+			if opts.MemberOnly && !ch.IsMember && !ch.IsIM {
+				continue
 			}
+			if prefix != "" && !strings.HasPrefix(strings.ToLower(ch.Name), prefix) {
+				continue
+			}
+			channels = append(channels, ChannelInfo{
+				ID:         ch.ID,
+				Name:       ch.Name,
+				IsPrivate:  ch.IsPrivate,
+				IsMember:   ch.IsMember,
+				IsArchived: ch.IsArchived,
+				NumMembers: ch.NumMembers,
+				Topic:      ch.Topic.Value,
+				Purpose:    ch.Purpose.Value,
+			})
 		}
 
-		if nextCursor == "" {
+		if nextCursor == "" || len(channels) >= limit {
 			break
 		}
 		params.Cursor = nextCursor
 	}
 
-	return "", apperr.New(apperr.CodeChannelNotFound,
-		"指定されたチャンネルが見つかりません: "+name, nil)
+	return &ListChannelsResult{
+		Channels:   channels,
+		HasMore:    nextCursor != "",
+		NextCursor: nextCursor,
+		Count:      len(channels),
+	}, nil
 }
@@ -3,104 +3,230 @@ package slack
 import (
 	"context"
 	"fmt"
-	"math"
+	"log/slog"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/kai-kou/slack-fast-mcp/internal/cache"
 	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	"github.com/kai-kou/slack-fast-mcp/internal/logging"
 	slackapi "github.com/slack-go/slack"
 )
 
-// maxRetries はレート制限時の最大リトライ回数。
-const maxRetries = 3
-
 // Client はSlack APIクライアントの実装。
+// 複数のゴルーチン（MCP の並行ツール呼び出し）から同時に使用できる。
 type Client struct {
 	api          *slackapi.Client
-	channelCache map[string]string // チャンネル名 → ID キャッシュ
+	userAPI      *slackapi.Client        // ユーザートークンのクライアント（検索用、未設定時は nil）
+	userToken    string                  // userAPI 生成用のユーザートークン
+	logger       *slog.Logger            // stderr 向けのログ（未設定時は出力しない）
+	retry        RetryPolicy             // 一時的なエラーのリトライ方針
+	limiter      *rateLimiter            // API メソッドごとのレート制限（nil の場合は制限しない）
+	channelCache map[string]string       // チャンネル名 → ID キャッシュ
+	maxScanPages int                     // 名前解決時の conversations.list の最大ページ数（0 は無制限）
+	users        *userCache              // ユーザーID → ユーザー名 キャッシュ
+	disk         *cache.Store            // プロセスをまたぐディスクキャッシュ（未設定時は nil）
+	idempotency  *cache.IdempotencyStore // idempotency_key → 投稿結果（nil の場合はキーを無視する）
+	scopes       *scopeRecorder          // Bot トークンに付与されたスコープ（NewClientWithAPI の場合は nil）
+	userScopes   *scopeRecorder          // ユーザートークンに付与されたスコープ（未設定時は nil）
+
+	// channelCache と索引の状態（バックグラウンドのウォームアップと並行して参照される）
+	channelMu            sync.Mutex
+	channelIndexComplete bool              // 全ページを読み切った索引かどうか
+	channelIndexAt       time.Time         // 索引を構築した時刻
+	dmCache              map[string]string // ユーザーID → DMチャンネルID
+	userIndex            map[string]string // ユーザー名・表示名（小文字） → ユーザーID
+
+	// 同時に発生した同じ問い合わせを1回の API 呼び出しにまとめる
+	indexFlight flightGroup[int]           // チャンネル索引の構築
+	userFlight  flightGroup[string]        // users.info（キーはユーザーID）
+	postFlight  flightGroup[*PostResult]   // 同じ idempotency_key の投稿（キーはチームID/キー）
+	authFlight  flightGroup[workspaceInfo] // ワークスペース情報の auth.test
+
+	// auth.test で取得するワークスペースURLとチームID（パーマリンクのローカル生成・ディスクキャッシュのキー用）
+	workspaceMu       sync.Mutex
+	workspace         workspaceInfo
+	workspaceFetched  bool      // 取得に成功した場合のみ true
+	workspaceFailedAt time.Time // 最後に取得に失敗した時刻（workspaceRetryInterval の間は再試行しない）
+}
+
+// ClientOption は Client の生成オプション。
+type ClientOption func(*Client)
+
+// WithUserToken は検索など Bot トークンでは呼び出せない API 用のユーザートークンを設定する。
+// 空文字の場合は何もしない。
+func WithUserToken(token string) ClientOption {
+	return func(c *Client) {
+		c.userToken = token
+	}
+}
+
+// WithLogger はログの出力先を設定する。nil の場合は何もしない（ログを出力しない）。
+// debug レベルが有効な場合は Slack API のリクエスト/レスポンスも出力する。
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithDiskCache はチャンネル名・ユーザー名のディスクキャッシュを設定する。
+// nil の場合は何もしない（メモリキャッシュのみ）。
+func WithDiskCache(store *cache.Store) ClientOption {
+	return func(c *Client) {
+		if store != nil {
+			c.disk = store
+		}
+	}
+}
+
+// WithChannelScanLimit はチャンネル名の解決で conversations.list を読む最大ページ数（1ページ200件）を設定する。
+// 0 以下の場合は無制限（ctx のキャンセルまで全ページを読む）。
+func WithChannelScanLimit(pages int) ClientOption {
+	return func(c *Client) {
+		c.maxScanPages = pages
+	}
 }
 
 // NewClient は新しいSlackクライアントを作成する。
-func NewClient(token string) *Client {
-	return &Client{
-		api:          slackapi.New(token),
-		channelCache: make(map[string]string),
+func NewClient(token string, opts ...ClientOption) *Client {
+	c := NewClientWithAPI(nil)
+	c.limiter = newRateLimiter(nil)
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// ロガーが決まってから API クライアントを生成する（debug 時は HTTP 通信をトレースする）
+	transport := http.DefaultTransport
+	if c.logger.Enabled(context.Background(), slog.LevelDebug) {
+		transport = &traceTransport{base: transport, logger: c.logger}
 	}
+	c.scopes = &scopeRecorder{base: transport}
+	c.api = slackapi.New(token, slackapi.OptionHTTPClient(&http.Client{Transport: c.scopes}))
+	if c.userToken != "" {
+		c.userScopes = &scopeRecorder{base: transport}
+		c.userAPI = slackapi.New(c.userToken, slackapi.OptionHTTPClient(&http.Client{Transport: c.userScopes}))
+	}
+	return c
 }
 
 // NewClientWithAPI は既存のslack.Clientを使用してクライアントを作成する（テスト用）。
+// クライアント側のレート制限は WithRateLimits を適用した場合のみ行う。
 func NewClientWithAPI(api *slackapi.Client) *Client {
 	return &Client{
 		api:          api,
 		channelCache: make(map[string]string),
+		dmCache:      make(map[string]string),
+		users:        newUserCache(userCacheTTL),
+		logger:       logging.Discard(),
+		retry:        DefaultRetryPolicy(),
+		idempotency:  cache.NewIdempotencyStore("", cache.DefaultIdempotencyWindow),
 	}
 }
 
 // PostMessage はチャンネルにメッセージを投稿する。
-func (c *Client) PostMessage(ctx context.Context, channel, message string) (*PostResult, error) {
+// opts.IdempotencyKey を指定した場合、同じキーでの再送には投稿せず最初の結果を返す。
+func (c *Client) PostMessage(ctx context.Context, channel, message string, opts PostOptions) (*PostResult, error) {
+	return c.idempotentPost(ctx, opts.IdempotencyKey, postFingerprint(channel, "", message, opts), func() (*PostResult, error) {
+		return c.postMessage(ctx, channel, "", message, opts)
+	})
+}
+
+// PostThread はスレッドに返信を投稿する。
+// opts.IdempotencyKey を指定した場合、同じキーでの再送には投稿せず最初の結果を返す。
+func (c *Client) PostThread(ctx context.Context, channel, threadTS, message string, opts PostOptions) (*PostResult, error) {
+	return c.idempotentPost(ctx, opts.IdempotencyKey, postFingerprint(channel, threadTS, message, opts), func() (*PostResult, error) {
+		return c.postMessage(ctx, channel, threadTS, message, opts)
+	})
+}
+
+// postMessage は chat.postMessage でメッセージを投稿する。threadTS を指定した場合はスレッドに返信する。
+func (c *Client) postMessage(ctx context.Context, channel, threadTS, message string, opts PostOptions) (*PostResult, error) {
+	msgOpts, err := messageOptions(message, opts)
+	if err != nil {
+		return nil, err
+	}
+	if threadTS != "" {
+		msgOpts = append(msgOpts, slackapi.MsgOptionTS(threadTS))
+	}
+
 	channelID, err := c.resolveChannel(ctx, channel)
 	if err != nil {
 		return nil, err
 	}
 
 	var respChannel, respTS string
-	err = c.withRetry(ctx, func() error {
+	err = c.withChannelRetry(ctx, "chat.postMessage", channelID, func() error {
 		var e error
-		respChannel, respTS, e = c.api.PostMessageContext(ctx, channelID,
-			slackapi.MsgOptionText(message, false),
-		)
+		respChannel, respTS, e = c.api.PostMessageContext(ctx, channelID, msgOpts...)
 		return e
 	})
 	if err != nil {
-		return nil, classifySlackError(err)
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
 	}
 
-	permalink, _ := c.api.GetPermalinkContext(ctx, &slackapi.PermalinkParameters{
-		Channel: respChannel,
-		Ts:      respTS,
-	})
-
 	return &PostResult{
 		Channel:     respChannel,
 		ChannelName: c.getChannelName(channel, channelID),
 		TS:          respTS,
+		ThreadTS:    threadTS,
 		Message:     message,
-		Permalink:   permalink,
+		Permalink:   c.permalink(ctx, respChannel, respTS, threadTS),
 	}, nil
 }
 
-// PostThread はスレッドに返信を投稿する。
-func (c *Client) PostThread(ctx context.Context, channel, threadTS, message string) (*PostResult, error) {
+// UpdateMessage は投稿済みメッセージの本文を更新する（chat.update）。
+func (c *Client) UpdateMessage(ctx context.Context, channel, timestamp, message string) (*PostResult, error) {
 	channelID, err := c.resolveChannel(ctx, channel)
 	if err != nil {
 		return nil, err
 	}
 
 	var respChannel, respTS string
-	err = c.withRetry(ctx, func() error {
+	err = c.withRetry(ctx, "chat.update", func() error {
 		var e error
-		respChannel, respTS, e = c.api.PostMessageContext(ctx, channelID,
+		respChannel, respTS, _, e = c.api.UpdateMessageContext(ctx, channelID, timestamp,
 			slackapi.MsgOptionText(message, false),
-			slackapi.MsgOptionTS(threadTS),
 		)
 		return e
 	})
 	if err != nil {
-		return nil, classifySlackError(err)
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
 	}
 
-	permalink, _ := c.api.GetPermalinkContext(ctx, &slackapi.PermalinkParameters{
-		Channel: respChannel,
-		Ts:      respTS,
-	})
-
 	return &PostResult{
 		Channel:     respChannel,
 		ChannelName: c.getChannelName(channel, channelID),
 		TS:          respTS,
-		ThreadTS:    threadTS,
 		Message:     message,
-		Permalink:   permalink,
+		Permalink:   c.permalink(ctx, respChannel, respTS, ""),
+	}, nil
+}
+
+// DeleteMessage は投稿済みメッセージを削除する（chat.delete）。
+func (c *Client) DeleteMessage(ctx context.Context, channel, timestamp string) (*DeleteResult, error) {
+	channelID, err := c.resolveChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	var respChannel, respTS string
+	err = c.withRetry(ctx, "chat.delete", func() error {
+		var e error
+		respChannel, respTS, e = c.api.DeleteMessageContext(ctx, channelID, timestamp)
+		return e
+	})
+	if err != nil {
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
+	}
+
+	return &DeleteResult{
+		Channel:     respChannel,
+		ChannelName: c.getChannelName(channel, channelID),
+		TS:          respTS,
 	}, nil
 }
 
@@ -130,57 +256,108 @@ func (c *Client) GetHistory(ctx context.Context, channel string, opts HistoryOpt
 	if opts.Latest != "" {
 		params.Latest = opts.Latest
 	}
+	if opts.Cursor != "" {
+		params.Cursor = opts.Cursor
+	}
 
 	var resp *slackapi.GetConversationHistoryResponse
-	err = c.withRetry(ctx, func() error {
+	err = c.withRetry(ctx, "conversations.history", func() error {
 		var e error
 		resp, e = c.api.GetConversationHistoryContext(ctx, params)
 		return e
 	})
 	if err != nil {
-		return nil, classifySlackError(err)
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
 	}
 	if resp != nil && !resp.Ok && resp.Error != "" {
-		return nil, classifySlackErrorString(resp.Error)
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackErrorString(resp.Error))
 	}
 
-	messages := make([]HistoryMessage, 0, len(resp.Messages))
-	for _, msg := range resp.Messages {
-		hm := HistoryMessage{
-			User:       msg.User,
-			Text:       msg.Text,
-			TS:         msg.Timestamp,
-			ThreadTS:   msg.ThreadTimestamp,
-			ReplyCount: msg.ReplyCount,
-		}
+	messages := c.toHistoryMessages(ctx, channelID, resp.Messages)
 
-		// ユーザー名解決（ベストエフォート）
-		if msg.User != "" {
-			if user, err := c.api.GetUserInfoContext(ctx, msg.User); err == nil {
-				hm.UserName = user.Name
-			}
-		}
+	return &HistoryResult{
+		Channel:     channelID,
+		ChannelName: c.getChannelName(channel, channelID),
+		Messages:    messages,
+		HasMore:     resp.HasMore,
+		NextCursor:  resp.ResponseMetaData.NextCursor,
+		Count:       len(messages),
+	}, nil
+}
 
-		// パーマリンク取得（ベストエフォート）
-		if permalink, err := c.api.GetPermalinkContext(ctx, &slackapi.PermalinkParameters{
-			Channel: channelID,
-			Ts:      msg.Timestamp,
-		}); err == nil {
-			hm.Permalink = permalink
-		}
+// GetThreadReplies はスレッドの返信一覧を取得する（conversations.replies）。
+func (c *Client) GetThreadReplies(ctx context.Context, channel, threadTS string, opts ThreadOptions) (*ThreadResult, error) {
+	channelID, err := c.resolveChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
 
-		messages = append(messages, hm)
+	// limit のバリデーション
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
 	}
 
-	return &HistoryResult{
+	params := &slackapi.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: threadTS,
+		Limit:     limit,
+		Cursor:    opts.Cursor,
+	}
+
+	var msgs []slackapi.Message
+	var hasMore bool
+	var nextCursor string
+	err = c.withRetry(ctx, "conversations.replies", func() error {
+		var e error
+		msgs, hasMore, nextCursor, e = c.api.GetConversationRepliesContext(ctx, params)
+		return e
+	})
+	if err != nil {
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
+	}
+
+	messages := c.toHistoryMessages(ctx, channelID, msgs)
+
+	return &ThreadResult{
 		Channel:     channelID,
 		ChannelName: c.getChannelName(channel, channelID),
+		ThreadTS:    threadTS,
 		Messages:    messages,
-		HasMore:     resp.HasMore,
+		HasMore:     hasMore,
+		NextCursor:  nextCursor,
 		Count:       len(messages),
 	}, nil
 }
 
+// toHistoryMessages はSlack APIのメッセージを HistoryMessage に変換する。
+// ユーザー名は重複排除・キャッシュ経由でまとめて解決し、パーマリンクはローカルで生成する
+// （いずれもベストエフォート）。
+func (c *Client) toHistoryMessages(ctx context.Context, channelID string, msgs []slackapi.Message) []HistoryMessage {
+	userIDs := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		userIDs = append(userIDs, msg.User)
+	}
+	userNames := c.resolveUserNames(ctx, userIDs)
+
+	messages := make([]HistoryMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		messages = append(messages, HistoryMessage{
+			User:       msg.User,
+			UserName:   userNames[msg.User],
+			Text:       msg.Text,
+			TS:         msg.Timestamp,
+			ThreadTS:   msg.ThreadTimestamp,
+			ReplyCount: msg.ReplyCount,
+			Permalink:  c.permalink(ctx, channelID, msg.Timestamp, msg.ThreadTimestamp),
+		})
+	}
+	return messages
+}
+
 // AddReaction はメッセージにリアクション（絵文字）を追加する。
 func (c *Client) AddReaction(ctx context.Context, channel, timestamp, reaction string) (*ReactionResult, error) {
 	channelID, err := c.resolveChannel(ctx, channel)
@@ -189,11 +366,11 @@ func (c *Client) AddReaction(ctx context.Context, channel, timestamp, reaction s
 	}
 
 	itemRef := slackapi.NewRefToMessage(channelID, timestamp)
-	err = c.withRetry(ctx, func() error {
+	err = c.withRetry(ctx, "reactions.add", func() error {
 		return c.api.AddReactionContext(ctx, reaction, itemRef)
 	})
 	if err != nil {
-		return nil, classifySlackError(err)
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
 	}
 
 	return &ReactionResult{
@@ -212,11 +389,11 @@ func (c *Client) RemoveReaction(ctx context.Context, channel, timestamp, reactio
 	}
 
 	itemRef := slackapi.NewRefToMessage(channelID, timestamp)
-	err = c.withRetry(ctx, func() error {
+	err = c.withRetry(ctx, "reactions.remove", func() error {
 		return c.api.RemoveReactionContext(ctx, reaction, itemRef)
 	})
 	if err != nil {
-		return nil, classifySlackError(err)
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
 	}
 
 	return &ReactionResult{
@@ -232,42 +409,6 @@ func (c *Client) ResolveChannel(ctx context.Context, channel string) (string, er
 	return c.resolveChannel(ctx, channel)
 }
 
-// withRetry はレート制限時に指数バックオフでリトライする。
-func (c *Client) withRetry(ctx context.Context, fn func() error) error {
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		err := fn()
-		if err == nil {
-			return nil
-		}
-
-		// レート制限エラーの場合はリトライ
-		if rateLimitErr, ok := err.(*slackapi.RateLimitedError); ok {
-			if attempt >= maxRetries {
-				return apperr.New(apperr.CodeRateLimited,
-					"レート制限に到達しました", err)
-			}
-
-			// Retry-After ヘッダに従う
-			waitDuration := rateLimitErr.RetryAfter
-			if waitDuration == 0 {
-				// フォールバック: 指数バックオフ (1s, 2s, 4s)
-				waitDuration = time.Duration(math.Pow(2, float64(attempt))) * time.Second
-			}
-
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(waitDuration):
-				continue
-			}
-		}
-
-		// レート制限以外のエラーはリトライしない
-		return err
-	}
-	return nil
-}
-
 // classifySlackError はSlack APIエラーをAppErrorに変換する。
 func classifySlackError(err error) error {
 	if err == nil {
@@ -291,12 +432,30 @@ func classifySlackErrorString(errStr string) error {
 		return apperr.New(apperr.CodeMissingScope, "必要なOAuthスコープが不足しています", fmt.Errorf("%s", errStr))
 	case strings.Contains(errStr, "thread_not_found"):
 		return apperr.New(apperr.CodeThreadNotFound, "スレッド元メッセージが見つかりません", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "message_not_found"):
+		return apperr.New(apperr.CodeMessageNotFound, "指定されたメッセージが見つかりません", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "cant_update_message"), strings.Contains(errStr, "edit_window_closed"):
+		return apperr.New(apperr.CodeCantUpdateMessage, "このメッセージは編集できません", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "cant_delete_message"):
+		return apperr.New(apperr.CodeCantDeleteMessage, "このメッセージは削除できません", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "invalid_blocks"):
+		return apperr.New(apperr.CodeInvalidBlocks, "Block Kit ブロックが不正です", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "users_not_found"), strings.Contains(errStr, "user_not_found"):
+		return apperr.New(apperr.CodeUserNotFound, "指定されたユーザーが見つかりません", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "user_disabled"), strings.Contains(errStr, "cannot_dm_bot"):
+		return apperr.New(apperr.CodeUserNotFound, "このユーザーにはDMを送信できません", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "time_in_past"), strings.Contains(errStr, "time_too_far"), strings.Contains(errStr, "invalid_time"):
+		return apperr.New(apperr.CodeInvalidPostAt, "予約時刻が不正です（未来かつ120日以内で指定してください）", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "invalid_scheduled_message_id"):
+		return apperr.New(apperr.CodeScheduledMessageNotFound, "予約投稿が見つかりません", fmt.Errorf("%s", errStr))
 	case strings.Contains(errStr, "no_text"):
 		return apperr.New(apperr.CodeNoText, "メッセージが空です", fmt.Errorf("%s", errStr))
 	case strings.Contains(errStr, "already_reacted"):
 		return apperr.New(apperr.CodeAlreadyReacted, "既にこの絵文字でリアクション済みです", fmt.Errorf("%s", errStr))
 	case strings.Contains(errStr, "no_reaction"):
 		return apperr.New(apperr.CodeNoReaction, "この絵文字のリアクションが存在しません", fmt.Errorf("%s", errStr))
+	case strings.Contains(errStr, "not_allowed_token_type"):
+		return apperr.New(apperr.CodeUserTokenNotConfigured, "このAPIはユーザートークンでのみ呼び出せます", fmt.Errorf("%s", errStr))
 	case strings.Contains(errStr, "invalid_name"):
 		return apperr.New(apperr.CodeInvalidReaction, "絵文字名が無効です", fmt.Errorf("%s", errStr))
 	default:
@@ -309,6 +468,8 @@ func classifySlackErrorString(errStr string) error {
 func (c *Client) getChannelName(input, resolvedID string) string {
 	if IsChannelID(input) {
 		// 逆引きキャッシュ確認
+		c.channelMu.Lock()
+		defer c.channelMu.Unlock()
 		for name, id := range c.channelCache {
 			if id == resolvedID {
 				return name
@@ -9,11 +9,12 @@ import (
 	"strings"
 	"testing"
 
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
 	slackapi "github.com/slack-go/slack"
 )
 
 // newMockSlackServer はSlack APIのモックHTTPサーバーを作成する。
-func newMockSlackServer(t *testing.T, handlers map[string]http.HandlerFunc) (*httptest.Server, *slackapi.Client) {
+func newMockSlackServer(t testing.TB, handlers map[string]http.HandlerFunc) (*httptest.Server, *slackapi.Client) {
 	t.Helper()
 	mux := http.NewServeMux()
 	for path, handler := range handlers {
@@ -51,7 +52,7 @@ func TestClient_PostMessage_Success(t *testing.T) {
 	})
 
 	client := NewClientWithAPI(api)
-	result, err := client.PostMessage(context.Background(), "C01234ABCDE", "Hello World")
+	result, err := client.PostMessage(context.Background(), "C01234ABCDE", "Hello World", PostOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -85,7 +86,7 @@ func TestClient_PostThread_Success(t *testing.T) {
 	})
 
 	client := NewClientWithAPI(api)
-	result, err := client.PostThread(context.Background(), "C01234ABCDE", "1234567890.123456", "Thread reply")
+	result, err := client.PostThread(context.Background(), "C01234ABCDE", "1234567890.123456", "Thread reply", PostOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -178,6 +179,37 @@ func TestClient_GetHistory_LimitValidation(t *testing.T) {
 	}
 }
 
+// --- S05b: GetHistory カーソルページネーション ---
+func TestClient_GetHistory_Cursor(t *testing.T) {
+	var gotCursor string
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.history": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			gotCursor = r.FormValue("cursor")
+			jsonResponse(w, map[string]any{
+				"ok":       true,
+				"messages": []map[string]any{},
+				"has_more": true,
+				"response_metadata": map[string]any{
+					"next_cursor": "page-3",
+				},
+			})
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	result, err := client.GetHistory(context.Background(), "C01234ABCDE", HistoryOptions{Cursor: "page-2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotCursor != "page-2" {
+		t.Errorf("cursor param = %q, want %q", gotCursor, "page-2")
+	}
+	if result.NextCursor != "page-3" {
+		t.Errorf("NextCursor = %q, want %q", result.NextCursor, "page-3")
+	}
+}
+
 // --- S10: エラー channel_not_found ---
 func TestClient_PostMessage_ChannelNotFound(t *testing.T) {
 	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
@@ -190,7 +222,7 @@ func TestClient_PostMessage_ChannelNotFound(t *testing.T) {
 	})
 
 	client := NewClientWithAPI(api)
-	_, err := client.PostMessage(context.Background(), "C01234ABCDE", "Hello")
+	_, err := client.PostMessage(context.Background(), "C01234ABCDE", "Hello", PostOptions{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -211,7 +243,7 @@ func TestClient_PostMessage_NotInChannel(t *testing.T) {
 	})
 
 	client := NewClientWithAPI(api)
-	_, err := client.PostMessage(context.Background(), "C01234ABCDE", "Hello")
+	_, err := client.PostMessage(context.Background(), "C01234ABCDE", "Hello", PostOptions{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -232,7 +264,7 @@ func TestClient_PostMessage_InvalidAuth(t *testing.T) {
 	})
 
 	client := NewClientWithAPI(api)
-	_, err := client.PostMessage(context.Background(), "C01234ABCDE", "Hello")
+	_, err := client.PostMessage(context.Background(), "C01234ABCDE", "Hello", PostOptions{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -254,7 +286,7 @@ func TestClient_PostMessage_ContextCancelled(t *testing.T) {
 	cancel() // 即座にキャンセル
 
 	client := NewClientWithAPI(api)
-	_, err := client.PostMessage(ctx, "C01234ABCDE", "Hello")
+	_, err := client.PostMessage(ctx, "C01234ABCDE", "Hello", PostOptions{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -290,7 +322,7 @@ func TestClient_PostMessage_WithChannelName(t *testing.T) {
 	})
 
 	client := NewClientWithAPI(api)
-	result, err := client.PostMessage(context.Background(), "general", "Hello")
+	result, err := client.PostMessage(context.Background(), "general", "Hello", PostOptions{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -326,6 +358,40 @@ func TestClient_ResolveChannel_NotFound(t *testing.T) {
 	}
 }
 
+// --- S09b: ResolveChannel 存在しないチャンネルの修正候補（複数ページから収集） ---
+func TestClient_ResolveChannel_NotFoundSuggestions(t *testing.T) {
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.list": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			if r.FormValue("cursor") == "" {
+				jsonResponse(w, map[string]any{
+					"ok":                true,
+					"channels":          []map[string]any{{"id": "C00000000A1", "name": "general"}, {"id": "C00000000A2", "name": "random"}},
+					"response_metadata": map[string]any{"next_cursor": "page2"},
+				})
+				return
+			}
+			jsonResponse(w, map[string]any{
+				"ok":                true,
+				"channels":          []map[string]any{{"id": "C00000000A3", "name": "general-ja"}},
+				"response_metadata": map[string]any{"next_cursor": ""},
+			})
+		},
+	})
+
+	_, err := NewClientWithAPI(api).ResolveChannel(context.Background(), "general-jp")
+	appErr, ok := err.(*apperr.AppError)
+	if !ok || appErr.Code != apperr.CodeChannelNotFound {
+		t.Fatalf("error = %v, want channel_not_found AppError", err)
+	}
+	if strings.Join(appErr.Suggestions, ",") != "general-ja,general" {
+		t.Errorf("Suggestions = %v, want [general-ja general]", appErr.Suggestions)
+	}
+	if !strings.Contains(appErr.FormatForMCP(), "Did you mean: general-ja, general") {
+		t.Errorf("FormatForMCP() = %q, want suggestions", appErr.FormatForMCP())
+	}
+}
+
 // --- S06/S07: ResolveChannel チャンネルID / ハッシュ付きチャンネル名 ---
 func TestClient_ResolveChannel_Patterns(t *testing.T) {
 	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
@@ -509,6 +575,11 @@ func TestClassifySlackError(t *testing.T) {
 		{"already_reacted", "already_reacted"},
 		{"no_reaction", "no_reaction"},
 		{"invalid_name", "invalid_reaction"},
+		{"message_not_found", "message_not_found"},
+		{"cant_update_message", "cant_update_message"},
+		{"edit_window_closed", "cant_update_message"},
+		{"cant_delete_message", "cant_delete_message"},
+		{"not_allowed_token_type", "user_token_not_configured"},
 		{"unknown_error", "network_error"},
 	}
 
@@ -530,3 +601,471 @@ func TestClassifySlackError_Nil(t *testing.T) {
 		t.Error("expected nil for nil input")
 	}
 }
+
+// --- S25: GetThreadReplies 正常系 ---
+func TestClient_GetThreadReplies_Success(t *testing.T) {
+	var gotCursor, gotTS string
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.replies": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			gotCursor = r.FormValue("cursor")
+			gotTS = r.FormValue("ts")
+			jsonResponse(w, map[string]any{
+				"ok": true,
+				"messages": []map[string]any{
+					{"user": "U01234ABCDE", "text": "Parent", "ts": "1234567890.111111", "thread_ts": "1234567890.111111", "reply_count": 1},
+					{"user": "U01234ABCDE", "text": "Reply", "ts": "1234567890.222222", "thread_ts": "1234567890.111111"},
+				},
+				"has_more": true,
+				"response_metadata": map[string]any{
+					"next_cursor": "bmV4dA==",
+				},
+			})
+		},
+		"/users.info": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{
+				"ok":   true,
+				"user": map[string]any{"id": "U01234ABCDE", "name": "testuser"},
+			})
+		},
+		"/chat.getPermalink": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "permalink": "https://test.slack.com/archives/C01234ABCDE/p1"})
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	result, err := client.GetThreadReplies(context.Background(), "C01234ABCDE", "1234567890.111111", ThreadOptions{Cursor: "cHJldg=="})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotTS != "1234567890.111111" {
+		t.Errorf("ts param = %q, want %q", gotTS, "1234567890.111111")
+	}
+	if gotCursor != "cHJldg==" {
+		t.Errorf("cursor param = %q, want %q", gotCursor, "cHJldg==")
+	}
+	if result.Count != 2 {
+		t.Fatalf("Count = %d, want 2", result.Count)
+	}
+	if result.Messages[1].Text != "Reply" || result.Messages[1].UserName != "testuser" {
+		t.Errorf("Messages[1] = %+v, want Reply by testuser", result.Messages[1])
+	}
+	if !result.HasMore || result.NextCursor != "bmV4dA==" {
+		t.Errorf("HasMore/NextCursor = %v/%q, want true/%q", result.HasMore, result.NextCursor, "bmV4dA==")
+	}
+}
+
+// --- S26: GetThreadReplies thread_not_found ---
+func TestClient_GetThreadReplies_ThreadNotFound(t *testing.T) {
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.replies": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": false, "error": "thread_not_found"})
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	_, err := client.GetThreadReplies(context.Background(), "C01234ABCDE", "1.2", ThreadOptions{})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "thread_not_found") {
+		t.Errorf("error = %q, want to contain %q", err.Error(), "thread_not_found")
+	}
+}
+
+// --- S27: SearchMessages 正常系（修飾子付与・チャンネル情報） ---
+func TestClient_SearchMessages_Success(t *testing.T) {
+	var gotQuery, gotSort, gotCount string
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/search.messages": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			gotQuery = r.FormValue("query")
+			gotSort = r.FormValue("sort")
+			gotCount = r.FormValue("count")
+			jsonResponse(w, map[string]any{
+				"ok":    true,
+				"query": gotQuery,
+				"messages": map[string]any{
+					"total":  41,
+					"paging": map[string]any{"count": 2, "total": 41, "page": 1, "pages": 21},
+					"matches": []map[string]any{
+						{
+							"channel":   map[string]any{"id": "C01234ABCDE", "name": "general"},
+							"user":      "U01234ABCDE",
+							"username":  "alice",
+							"text":      "deploy failed",
+							"ts":        "1234567890.222222",
+							"permalink": "https://test.slack.com/archives/C01234ABCDE/p1234567890222222?thread_ts=1234567890.111111&cid=C01234ABCDE",
+						},
+					},
+				},
+			})
+		},
+	})
+
+	client := NewClientWithAPI(slackapi.New("xoxb-bot"))
+	client.userAPI = api
+	result, err := client.SearchMessages(context.Background(), SearchOptions{
+		Query:   "deploy",
+		Channel: "general",
+		User:    "U01234ABCDE",
+		After:   "2024-01-01",
+		Sort:    "timestamp",
+		Count:   2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantQuery := "deploy in:#general from:<@U01234ABCDE> after:2024-01-01"
+	if gotQuery != wantQuery {
+		t.Errorf("query = %q, want %q", gotQuery, wantQuery)
+	}
+	if gotSort != "timestamp" || gotCount != "2" {
+		t.Errorf("sort/count = %q/%q, want timestamp/2", gotSort, gotCount)
+	}
+	if result.Total != 41 || result.Pages != 21 || result.Count != 1 {
+		t.Errorf("Total/Pages/Count = %d/%d/%d, want 41/21/1", result.Total, result.Pages, result.Count)
+	}
+	msg := result.Messages[0]
+	if msg.Channel != "C01234ABCDE" || msg.ChannelName != "general" {
+		t.Errorf("channel = %q/%q, want C01234ABCDE/general", msg.Channel, msg.ChannelName)
+	}
+	if msg.UserName != "alice" || msg.ThreadTS != "1234567890.111111" {
+		t.Errorf("UserName/ThreadTS = %q/%q, want alice/1234567890.111111", msg.UserName, msg.ThreadTS)
+	}
+}
+
+// --- S28: SearchMessages ユーザートークン未設定 ---
+func TestClient_SearchMessages_NoUserToken(t *testing.T) {
+	client := NewClient("xoxb-bot", WithUserToken(""))
+	_, err := client.SearchMessages(context.Background(), SearchOptions{Query: "deploy"})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	appErr, ok := err.(*apperr.AppError)
+	if !ok || appErr.Code != apperr.CodeUserTokenNotConfigured {
+		t.Errorf("error = %v, want code %s", err, apperr.CodeUserTokenNotConfigured)
+	}
+}
+
+// --- S29: SearchMessages Bot トークンで呼び出した場合 ---
+func TestClient_SearchMessages_BotToken(t *testing.T) {
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/search.messages": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": false, "error": "not_allowed_token_type"})
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	client.userAPI = api
+	_, err := client.SearchMessages(context.Background(), SearchOptions{Query: "deploy"})
+	if err == nil || !strings.Contains(err.Error(), apperr.CodeUserTokenNotConfigured) {
+		t.Errorf("error = %v, want to contain %q", err, apperr.CodeUserTokenNotConfigured)
+	}
+}
+
+// --- S30: 検索クエリの組み立て ---
+func TestBuildSearchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want string
+	}{
+		{"query only", SearchOptions{Query: "  deploy  "}, "deploy"},
+		{"channel ID", SearchOptions{Query: "deploy", Channel: "C01234ABCDE"}, "deploy in:<#C01234ABCDE>"},
+		{"channel name with #", SearchOptions{Query: "deploy", Channel: "#general"}, "deploy in:#general"},
+		{"user name with @", SearchOptions{Query: "deploy", User: "@alice"}, "deploy from:@alice"},
+		{"dates", SearchOptions{Query: "deploy", Before: "2024-02-01", On: "2024-01-15"}, "deploy before:2024-02-01 on:2024-01-15"},
+		{"modifiers only", SearchOptions{Channel: "general"}, "in:#general"},
+		{"empty", SearchOptions{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildSearchQuery(tt.opts); got != tt.want {
+				t.Errorf("buildSearchQuery = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// --- S31: UpdateMessage 正常系 ---
+func TestClient_UpdateMessage_Success(t *testing.T) {
+	var gotTS, gotText string
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.update": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			gotTS = r.FormValue("ts")
+			gotText = r.FormValue("text")
+			jsonResponse(w, map[string]any{"ok": true, "channel": "C01234ABCDE", "ts": gotTS, "text": gotText})
+		},
+		"/chat.getPermalink": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "permalink": "https://test.slack.com/archives/C01234ABCDE/p1234567890123456"})
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	result, err := client.UpdateMessage(context.Background(), "C01234ABCDE", "1234567890.123456", "Fixed status\n#bot")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotTS != "1234567890.123456" || gotText != "Fixed status\n#bot" {
+		t.Errorf("ts/text = %q/%q, want request to carry ts and new text", gotTS, gotText)
+	}
+	if result.TS != "1234567890.123456" || result.Permalink == "" {
+		t.Errorf("result = %+v, want ts and permalink", result)
+	}
+}
+
+// --- S32: DeleteMessage 正常系 / cant_delete_message ---
+func TestClient_DeleteMessage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+			"/chat.delete": func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				jsonResponse(w, map[string]any{"ok": true, "channel": r.FormValue("channel"), "ts": r.FormValue("ts")})
+			},
+		})
+
+		result, err := NewClientWithAPI(api).DeleteMessage(context.Background(), "C01234ABCDE", "1234567890.123456")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Channel != "C01234ABCDE" || result.TS != "1234567890.123456" {
+			t.Errorf("result = %+v, want channel and ts", result)
+		}
+	})
+
+	t.Run("not own message", func(t *testing.T) {
+		_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+			"/chat.delete": func(w http.ResponseWriter, r *http.Request) {
+				jsonResponse(w, map[string]any{"ok": false, "error": "cant_delete_message"})
+			},
+		})
+
+		_, err := NewClientWithAPI(api).DeleteMessage(context.Background(), "C01234ABCDE", "1234567890.123456")
+		if err == nil || !strings.Contains(err.Error(), "cant_delete_message") {
+			t.Errorf("error = %v, want to contain cant_delete_message", err)
+		}
+	})
+}
+
+// --- S33: ListChannels 前方一致・参加中フィルタとページング ---
+func TestClient_ListChannels_FilterAcrossPages(t *testing.T) {
+	var cursors []string
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.list": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			cursors = append(cursors, r.FormValue("cursor"))
+			if r.FormValue("exclude_archived") != "true" {
+				t.Errorf("exclude_archived = %q, want true", r.FormValue("exclude_archived"))
+			}
+			switch r.FormValue("cursor") {
+			case "":
+				jsonResponse(w, map[string]any{
+					"ok": true,
+					"channels": []map[string]any{
+						{"id": "C00000000A1", "name": "general", "is_member": true},
+						{"id": "C00000000A2", "name": "proj-alpha", "is_member": true, "num_members": 5,
+							"topic": map[string]any{"value": "alpha topic"}},
+						{"id": "C00000000A3", "name": "proj-beta", "is_member": false},
+					},
+					"response_metadata": map[string]any{"next_cursor": "page2"},
+				})
+			default:
+				jsonResponse(w, map[string]any{
+					"ok": true,
+					"channels": []map[string]any{
+						{"id": "C00000000A4", "name": "Proj-Gamma", "is_member": true, "is_private": true},
+					},
+					"response_metadata": map[string]any{"next_cursor": ""},
+				})
+			}
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	result, err := client.ListChannels(context.Background(), ListChannelsOptions{
+		Prefix:     "#proj-",
+		MemberOnly: true,
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(cursors) != 2 || cursors[1] != "page2" {
+		t.Errorf("cursors = %v, want [\"\" page2]", cursors)
+	}
+	if result.Count != 2 || result.HasMore {
+		t.Fatalf("result = %+v, want 2 channels and has_more=false", result)
+	}
+	if result.Channels[0].Name != "proj-alpha" || result.Channels[0].Topic != "alpha topic" || result.Channels[0].NumMembers != 5 {
+		t.Errorf("channels[0] = %+v, want proj-alpha with topic and members", result.Channels[0])
+	}
+	if result.Channels[1].ID != "C00000000A4" || !result.Channels[1].IsPrivate {
+		t.Errorf("channels[1] = %+v, want private Proj-Gamma", result.Channels[1])
+	}
+
+	// 取得したチャンネルは名前解決キャッシュに入る（追加の API 呼び出しなし）
+	id, err := client.ResolveChannel(context.Background(), "proj-beta")
+	if err != nil || id != "C00000000A3" {
+		t.Errorf("ResolveChannel = %q, %v; want cached C00000000A3", id, err)
+	}
+	if len(cursors) != 2 {
+		t.Errorf("conversations.list calls = %d, want 2", len(cursors))
+	}
+}
+
+// --- S34: ListChannels 型未指定時の missing_scope フォールバック ---
+func TestClient_ListChannels_MissingScopeFallback(t *testing.T) {
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.list": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			if strings.Contains(r.FormValue("types"), "private_channel") {
+				jsonResponse(w, map[string]any{"ok": false, "error": "missing_scope"})
+				return
+			}
+			jsonResponse(w, map[string]any{
+				"ok":                true,
+				"channels":          []map[string]any{{"id": "C01234ABCDE", "name": "general"}},
+				"response_metadata": map[string]any{"next_cursor": "next"},
+			})
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	result, err := client.ListChannels(context.Background(), ListChannelsOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Count != 1 || !result.HasMore || result.NextCursor != "next" {
+		t.Errorf("result = %+v, want 1 channel with next cursor", result)
+	}
+
+	// 型を明示した場合はフォールバックしない
+	_, err = client.ListChannels(context.Background(), ListChannelsOptions{Types: []string{"private_channel"}})
+	if err == nil || !strings.Contains(err.Error(), "missing_scope") {
+		t.Errorf("error = %v, want missing_scope", err)
+	}
+}
+
+func TestParseConversationTypes(t *testing.T) {
+	got := ParseConversationTypes(" public, PRIVATE ,im,,mpim")
+	want := []string{"public_channel", "private_channel", "im", "mpim"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ParseConversationTypes = %v, want %v", got, want)
+	}
+	if ParseConversationTypes("") != nil {
+		t.Error("ParseConversationTypes(\"\") should be nil")
+	}
+}
+
+// newPagedChannelsAPI は pages ページ（各 perPage 件）のチャンネルを返す conversations.list のモックを作成する。
+// チャンネル名は ch-<page>-<index>、IDは C<page><index> 形式。
+func newPagedChannelsAPI(t *testing.T, pages, perPage int, calls *int) *slackapi.Client {
+	t.Helper()
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.list": func(w http.ResponseWriter, r *http.Request) {
+			*calls++
+			r.ParseForm()
+			page := 0
+			if c := r.FormValue("cursor"); c != "" {
+				fmt.Sscanf(c, "page-%d", &page)
+			}
+			channels := make([]map[string]any, 0, perPage)
+			for i := 0; i < perPage; i++ {
+				channels = append(channels, map[string]any{
+					"id":   fmt.Sprintf("C%04d%06d", page, i),
+					"name": fmt.Sprintf("ch-%d-%d", page, i),
+				})
+			}
+			next := ""
+			if page+1 < pages {
+				next = fmt.Sprintf("page-%d", page+1)
+			}
+			jsonResponse(w, map[string]any{
+				"ok":                true,
+				"channels":          channels,
+				"response_metadata": map[string]any{"next_cursor": next},
+			})
+		},
+	})
+	return api
+}
+
+// --- S35: 1000 チャンネルを超えるワークスペースでの名前解決と索引の再利用 ---
+func TestClient_ResolveChannel_BeyondFivePages(t *testing.T) {
+	calls := 0
+	client := NewClientWithAPI(newPagedChannelsAPI(t, 15, 200, &calls))
+
+	id, err := client.ResolveChannel(context.Background(), "ch-14-199")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "C0014000199" {
+		t.Errorf("ID = %q, want %q", id, "C0014000199")
+	}
+	if calls != 15 {
+		t.Errorf("conversations.list calls = %d, want 15", calls)
+	}
+
+	// 構築済みの索引を再利用する（追加の API 呼び出しなし）
+	if _, err := client.ResolveChannel(context.Background(), "ch-3-7"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_, err = client.ResolveChannel(context.Background(), "ch-99-0")
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("error = %v, want channel_not_found", err)
+	}
+	if calls != 15 {
+		t.Errorf("conversations.list calls = %d, want 15 (index reused)", calls)
+	}
+}
+
+// --- S36: ページ数上限（channel_scan_max_pages） ---
+func TestClient_ResolveChannel_ScanLimit(t *testing.T) {
+	calls := 0
+	client := NewClientWithAPI(newPagedChannelsAPI(t, 10, 200, &calls))
+	WithChannelScanLimit(2)(client)
+
+	if _, err := client.ResolveChannel(context.Background(), "ch-1-0"); err != nil {
+		t.Fatalf("channel within limit: %v", err)
+	}
+
+	_, err := client.ResolveChannel(context.Background(), "ch-5-0")
+	if err == nil || !strings.Contains(err.Error(), "channel_scan_max_pages=2") {
+		t.Errorf("error = %v, want channel_not_found mentioning the page limit", err)
+	}
+	// 上限で打ち切った索引は不完全なため、見つからない名前では再走査する
+	if calls != 4 {
+		t.Errorf("conversations.list calls = %d, want 4", calls)
+	}
+}
+
+// --- S37: 走査中の context キャンセル ---
+func TestClient_WarmChannelIndex_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.list": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 3 {
+				cancel()
+			}
+			jsonResponse(w, map[string]any{
+				"ok":                true,
+				"channels":          []map[string]any{{"id": fmt.Sprintf("C%010d", calls), "name": fmt.Sprintf("ch-%d", calls)}},
+				"response_metadata": map[string]any{"next_cursor": "more"},
+			})
+		},
+	})
+
+	_, err := NewClientWithAPI(api).WarmChannelIndex(ctx)
+	if err == nil {
+		t.Fatal("expected error after cancellation")
+	}
+	if calls > 4 {
+		t.Errorf("conversations.list calls = %d, want pagination to stop after cancel", calls)
+	}
+}
@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"context"
+	"strings"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+)
+
+// ディスクキャッシュはベストエフォートで使用する。
+// 読み書きに失敗しても API 呼び出しにフォールバックするだけで、エラーにはしない。
+
+// diskTeamID はディスクキャッシュのキーとなるチームIDを返す。
+// ディスクキャッシュ未設定、または auth.test に失敗した場合は空文字を返す。
+func (c *Client) diskTeamID(ctx context.Context) string {
+	if c.disk == nil {
+		return ""
+	}
+	return c.workspaceTeamID(ctx)
+}
+
+// cachedChannelID はディスクキャッシュからチャンネルIDを取得する。
+func (c *Client) cachedChannelID(ctx context.Context, name string) (string, bool) {
+	team := c.diskTeamID(ctx)
+	if team == "" {
+		return "", false
+	}
+	return c.disk.Channel(team, name)
+}
+
+// storeChannelIDs はチャンネル名 → ID をメモリとディスクのキャッシュに保存する。
+func (c *Client) storeChannelIDs(ctx context.Context, channels map[string]string) {
+	if len(channels) == 0 {
+		return
+	}
+	c.channelMu.Lock()
+	for name, id := range channels {
+		c.channelCache[name] = id
+	}
+	c.channelMu.Unlock()
+	if team := c.diskTeamID(ctx); team != "" {
+		_ = c.disk.SetChannels(team, channels)
+	}
+}
+
+// cachedUserNames はディスクキャッシュからユーザー名を取得する。
+func (c *Client) cachedUserNames(ctx context.Context, ids []string) map[string]string {
+	team := c.diskTeamID(ctx)
+	if team == "" {
+		return nil
+	}
+	return c.disk.Users(team, ids)
+}
+
+// storeUserNames はユーザーID → ユーザー名をディスクキャッシュに保存する。
+func (c *Client) storeUserNames(ctx context.Context, users map[string]string) {
+	if len(users) == 0 {
+		return
+	}
+	if team := c.diskTeamID(ctx); team != "" {
+		_ = c.disk.SetUsers(team, users)
+	}
+}
+
+// invalidateOnChannelNotFound は channel_not_found の場合にチャンネル名のキャッシュを削除する。
+// キャッシュ済みのIDがチャンネルの削除・改名で無効になっても、次回の呼び出しで再検索されるようにする。
+// 受け取ったエラーはそのまま返す。
+func (c *Client) invalidateOnChannelNotFound(ctx context.Context, channel string, err error) error {
+	appErr, ok := err.(*apperr.AppError)
+	if !ok || appErr.Code != apperr.CodeChannelNotFound || IsChannelID(channel) {
+		return err
+	}
+
+	name := strings.TrimPrefix(channel, "#")
+	c.channelMu.Lock()
+	delete(c.channelCache, name)
+	// 索引が古くなっているため、次回の名前解決で再構築させる
+	c.channelIndexComplete = false
+	c.channelMu.Unlock()
+	if team := c.diskTeamID(ctx); team != "" {
+		_ = c.disk.DeleteChannel(team, name)
+	}
+	return err
+}
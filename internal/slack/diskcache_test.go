@@ -0,0 +1,124 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/cache"
+	slackapi "github.com/slack-go/slack"
+)
+
+// newDiskCacheMockAPI は conversations.list / chat.postMessage / users.info を提供するモックを作成する。
+// postError が空でない場合、chat.postMessage はそのエラーを返す。
+func newDiskCacheMockAPI(t *testing.T, listCalls, usersCalls *atomic.Int64, postError *string) *slackapi.Client {
+	t.Helper()
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/auth.test": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "url": "https://test.slack.com/", "team_id": "T01234ABCDE"})
+		},
+		"/conversations.list": func(w http.ResponseWriter, r *http.Request) {
+			listCalls.Add(1)
+			jsonResponse(w, map[string]any{
+				"ok": true,
+				"channels": []map[string]any{
+					{"id": "C01234ABCDE", "name": "general"},
+					{"id": "C09876ZZZZZ", "name": "random"},
+				},
+				"response_metadata": map[string]any{"next_cursor": ""},
+			})
+		},
+		"/chat.postMessage": func(w http.ResponseWriter, r *http.Request) {
+			if *postError != "" {
+				jsonResponse(w, map[string]any{"ok": false, "error": *postError})
+				return
+			}
+			r.ParseForm()
+			jsonResponse(w, map[string]any{"ok": true, "channel": r.FormValue("channel"), "ts": "1234567890.123456"})
+		},
+		"/conversations.history": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "messages": []map[string]any{
+				{"user": "U01234ABCDE", "text": "hi", "ts": "1700000000.000001"},
+			}})
+		},
+		"/users.info": func(w http.ResponseWriter, r *http.Request) {
+			usersCalls.Add(1)
+			jsonResponse(w, map[string]any{"ok": true, "user": map[string]any{"id": "U01234ABCDE", "name": "alice"}})
+		},
+	})
+	return api
+}
+
+// --- D01: ディスクキャッシュはクライアント（CLI 起動）をまたいで再利用される ---
+func TestClient_DiskCache_SharedAcrossClients(t *testing.T) {
+	var listCalls, usersCalls atomic.Int64
+	postError := ""
+	api := newDiskCacheMockAPI(t, &listCalls, &usersCalls, &postError)
+	store := cache.New(t.TempDir(), time.Hour)
+
+	// CLI の起動ごとにクライアントを作り直す（メモリキャッシュは共有しない）
+	newClient := func() *Client {
+		c := NewClientWithAPI(api)
+		WithDiskCache(store)(c)
+		return c
+	}
+
+	// 1回目: conversations.list を走査し、見つかったチャンネル以外もキャッシュされる
+	if _, err := newClient().PostMessage(context.Background(), "general", "hello", PostOptions{}); err != nil {
+		t.Fatalf("first post: %v", err)
+	}
+	if _, err := newClient().GetHistory(context.Background(), "random", HistoryOptions{}); err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if _, err := newClient().GetHistory(context.Background(), "#random", HistoryOptions{}); err != nil {
+		t.Fatalf("second history: %v", err)
+	}
+
+	if got := listCalls.Load(); got != 1 {
+		t.Errorf("conversations.list calls = %d, want 1", got)
+	}
+	if got := usersCalls.Load(); got != 1 {
+		t.Errorf("users.info calls = %d, want 1", got)
+	}
+
+	w, _ := store.Load("T01234ABCDE")
+	if w.Channels["random"].Value != "C09876ZZZZZ" || w.Users["U01234ABCDE"].Value != "alice" {
+		t.Errorf("cache = %+v, want channels and users keyed by team ID", w)
+	}
+}
+
+// --- D02: channel_not_found でキャッシュを無効化し、次回は再検索する ---
+func TestClient_DiskCache_InvalidateOnChannelNotFound(t *testing.T) {
+	var listCalls, usersCalls atomic.Int64
+	postError := ""
+	api := newDiskCacheMockAPI(t, &listCalls, &usersCalls, &postError)
+	store := cache.New(t.TempDir(), time.Hour)
+	store.SetChannels("T01234ABCDE", map[string]string{"general": "C0DELETED00", "random": "C09876ZZZZZ"})
+
+	client := NewClientWithAPI(api)
+	WithDiskCache(store)(client)
+
+	postError = "channel_not_found"
+	_, err := client.PostMessage(context.Background(), "general", "hello", PostOptions{})
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Fatalf("error = %v, want channel_not_found", err)
+	}
+	if _, ok := store.Channel("T01234ABCDE", "general"); ok {
+		t.Error("stale entry should be removed from disk cache")
+	}
+	if _, ok := store.Channel("T01234ABCDE", "random"); !ok {
+		t.Error("other entries should be kept")
+	}
+
+	postError = ""
+	result, err := client.PostMessage(context.Background(), "general", "hello", PostOptions{})
+	if err != nil {
+		t.Fatalf("retry post: %v", err)
+	}
+	if result.Channel != "C01234ABCDE" || listCalls.Load() != 1 {
+		t.Errorf("channel = %q, list calls = %d; want fresh lookup", result.Channel, listCalls.Load())
+	}
+}
@@ -0,0 +1,138 @@
+package slack
+
+import (
+	"context"
+	"strings"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackapi "github.com/slack-go/slack"
+)
+
+// IsDMTarget はチャンネル指定がDMの宛先（@ユーザー名・メールアドレス・ユーザーID）かどうかを判定する。
+// チャンネル名には "@" や英大文字を使えないため、通常のチャンネル名と衝突しない。
+func IsDMTarget(s string) bool {
+	return strings.HasPrefix(s, "@") || isEmail(s) || isUserID(s)
+}
+
+// isEmail はメールアドレス形式（local@domain.tld）かどうかを簡易判定する。
+func isEmail(s string) bool {
+	at := strings.Index(s, "@")
+	return at > 0 && strings.Contains(s[at+1:], ".") && !strings.ContainsAny(s, " \t")
+}
+
+// resolveDMChannel はDMの宛先を conversations.open でDMチャンネルIDに変換する（im:write スコープが必要）。
+// ユーザーID → DMチャンネルIDはクライアントの生存期間中キャッシュする。
+func (c *Client) resolveDMChannel(ctx context.Context, target string) (string, error) {
+	userID, err := c.resolveUserID(ctx, target)
+	if err != nil {
+		return "", err
+	}
+
+	c.channelMu.Lock()
+	id, ok := c.dmCache[userID]
+	c.channelMu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	var ch *slackapi.Channel
+	err = c.withRetry(ctx, "conversations.open", func() error {
+		var e error
+		ch, _, _, e = c.api.OpenConversationContext(ctx, &slackapi.OpenConversationParameters{
+			Users: []string{userID},
+		})
+		return e
+	})
+	if err != nil {
+		return "", classifySlackError(err)
+	}
+
+	c.channelMu.Lock()
+	c.dmCache[userID] = ch.ID
+	c.channelMu.Unlock()
+	return ch.ID, nil
+}
+
+// resolveUserID はDMの宛先をユーザーIDに変換する。
+// ユーザーID はそのまま、メールアドレスは users.lookupByEmail（users:read.email スコープ）、
+// @ユーザー名は users.list から構築した索引で解決する。
+func (c *Client) resolveUserID(ctx context.Context, target string) (string, error) {
+	switch {
+	case isUserID(target):
+		return target, nil
+	case isEmail(target):
+		var user *slackapi.User
+		err := c.withRetry(ctx, "users.lookupByEmail", func() error {
+			var e error
+			user, e = c.api.GetUserByEmailContext(ctx, target)
+			return e
+		})
+		if err != nil {
+			return "", classifySlackError(err)
+		}
+		c.users.set(user.ID, user.Name)
+		return user.ID, nil
+	}
+
+	name := strings.ToLower(strings.TrimPrefix(target, "@"))
+	if id, ok := c.lookupUserName(name); ok {
+		return id, nil
+	}
+	if _, err := c.buildUserIndex(ctx); err != nil {
+		return "", err
+	}
+	if id, ok := c.lookupUserName(name); ok {
+		return id, nil
+	}
+	return "", apperr.New(apperr.CodeUserNotFound, "指定されたユーザーが見つかりません: "+target, nil)
+}
+
+// lookupUserName はユーザー名の索引からユーザーIDを取得する。
+func (c *Client) lookupUserName(name string) (string, bool) {
+	c.channelMu.Lock()
+	defer c.channelMu.Unlock()
+	id, ok := c.userIndex[name]
+	return id, ok
+}
+
+// buildUserIndex は users.list を全件取得し、ユーザー名・表示名（小文字）→ ユーザーIDの索引を構築する。
+// 同時に呼び出された場合は1回の取得にまとめる。索引に登録したユーザー数を返す。
+func (c *Client) buildUserIndex(ctx context.Context) (int, error) {
+	count, err, _ := c.indexFlight.do(ctx, "users", func() (int, error) {
+		// GetUsersContext はページングとレート制限の待機を内部で行う
+		var users []slackapi.User
+		err := c.withRetry(ctx, "users.list", func() error {
+			var e error
+			users, e = c.api.GetUsersContext(ctx, slackapi.GetUsersOptionLimit(200))
+			return e
+		})
+		if err != nil {
+			return 0, classifySlackError(err)
+		}
+
+		index := make(map[string]string, len(users)*2)
+		for _, u := range users {
+			if u.Deleted {
+				continue
+			}
+			c.users.set(u.ID, u.Name)
+			// 表示名は重複し得るため、ユーザー名（一意）を優先する
+			if dn := strings.ToLower(u.Profile.DisplayName); dn != "" {
+				if _, ok := index[dn]; !ok {
+					index[dn] = u.ID
+				}
+			}
+		}
+		for _, u := range users {
+			if !u.Deleted {
+				index[strings.ToLower(u.Name)] = u.ID
+			}
+		}
+
+		c.channelMu.Lock()
+		c.userIndex = index
+		c.channelMu.Unlock()
+		return len(users), nil
+	})
+	return count, err
+}
@@ -0,0 +1,126 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newDMAPI は users.list / users.lookupByEmail / conversations.open / chat.postMessage を模擬する。
+func newDMAPI(t *testing.T, opens, lists *int32) *Client {
+	t.Helper()
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/users.list": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(lists, 1)
+			jsonResponse(w, map[string]any{
+				"ok": true,
+				"members": []map[string]any{
+					{"id": "U0000ALICE", "name": "alice", "profile": map[string]any{"display_name": "Alice A"}},
+					{"id": "U00000BOB1", "name": "bob", "profile": map[string]any{"display_name": "alice"}},
+					{"id": "U00000GONE", "name": "gone", "deleted": true},
+				},
+			})
+		},
+		"/users.lookupByEmail": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("email") != "bob@example.com" {
+				jsonResponse(w, map[string]any{"ok": false, "error": "users_not_found"})
+				return
+			}
+			jsonResponse(w, map[string]any{"ok": true, "user": map[string]any{"id": "U00000BOB1", "name": "bob"}})
+		},
+		"/conversations.open": func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(opens, 1)
+			jsonResponse(w, map[string]any{
+				"ok":      true,
+				"channel": map[string]any{"id": "D" + strings.TrimPrefix(r.FormValue("users"), "U")},
+			})
+		},
+		"/chat.postMessage": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "channel": r.FormValue("channel"), "ts": "1234567890.123456"})
+		},
+	})
+	return NewClientWithAPI(api)
+}
+
+// --- S39: @ユーザー名・メールアドレス・ユーザーIDからDMチャンネルを解決 ---
+func TestClient_ResolveChannel_DMTargets(t *testing.T) {
+	var opens, lists int32
+	client := newDMAPI(t, &opens, &lists)
+	ctx := context.Background()
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"@alice", "D0000ALICE"},
+		{"@Alice A", "D0000ALICE"},        // 表示名（大文字小文字を区別しない）
+		{"bob@example.com", "D00000BOB1"}, // users.lookupByEmail
+		{"U00000BOB1", "D00000BOB1"},      // ユーザーIDはそのまま
+		{"@bob", "D00000BOB1"},
+	}
+	for _, tt := range tests {
+		id, err := client.ResolveChannel(ctx, tt.target)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.target, err)
+		}
+		if id != tt.want {
+			t.Errorf("%s: ID = %q, want %q", tt.target, id, tt.want)
+		}
+	}
+
+	// ユーザー名は表示名より優先し、users.list と conversations.open の結果は再利用する
+	if id, _ := client.ResolveChannel(ctx, "@alice"); id != "D0000ALICE" {
+		t.Errorf("@alice resolved to %q, want username to win over display name", id)
+	}
+	if lists != 1 {
+		t.Errorf("users.list calls = %d, want 1", lists)
+	}
+	if opens != 2 {
+		t.Errorf("conversations.open calls = %d, want 2 (one per user)", opens)
+	}
+
+	// DMに投稿できる
+	result, err := client.PostMessage(ctx, "@bob", "hi", PostOptions{})
+	if err != nil {
+		t.Fatalf("PostMessage: %v", err)
+	}
+	if result.Channel != "D00000BOB1" {
+		t.Errorf("Channel = %q, want D00000BOB1", result.Channel)
+	}
+}
+
+// --- S40: 見つからないDMの宛先は user_not_found ---
+func TestClient_ResolveChannel_DMUserNotFound(t *testing.T) {
+	var opens, lists int32
+	client := newDMAPI(t, &opens, &lists)
+
+	for _, target := range []string{"@nobody", "@gone", "nobody@example.com"} {
+		_, err := client.ResolveChannel(context.Background(), target)
+		if err == nil || !strings.Contains(err.Error(), "user_not_found") {
+			t.Errorf("%s: error = %v, want user_not_found", target, err)
+		}
+	}
+	if opens != 0 {
+		t.Errorf("conversations.open calls = %d, want 0", opens)
+	}
+}
+
+func TestIsDMTarget(t *testing.T) {
+	tests := map[string]bool{
+		"@alice":            true,
+		"alice@example.com": true,
+		"U01234ABCDE":       true,
+		"W01234ABCDE":       true,
+		"general":           false,
+		"#general":          false,
+		"C01234ABCDE":       false,
+		"not-an@email":      false,
+	}
+	for in, want := range tests {
+		if got := IsDMTarget(in); got != want {
+			t.Errorf("IsDMTarget(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
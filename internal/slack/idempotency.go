@@ -0,0 +1,97 @@
+package slack
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/cache"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+)
+
+// WithIdempotencyStore は idempotency_key の保存先を設定する。nil の場合は idempotency_key を無視する。
+func WithIdempotencyStore(s *cache.IdempotencyStore) ClientOption {
+	return func(c *Client) {
+		c.idempotency = s
+	}
+}
+
+// postFingerprint は投稿内容のハッシュを返す（同じ idempotency_key で異なる内容が送られたことの検出用）。
+func postFingerprint(channel, threadTS, message string, opts PostOptions) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{channel, threadTS, message, opts.Blocks}, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// idempotentPost は key が空でなければ、有効期間内に同じキーで投稿済みの場合に post を呼ばず最初の結果を返す。
+// 同じキーの同時呼び出しは1回の投稿にまとめる。異なる内容で同じキーが使われた場合は idempotency_conflict、
+// 他のプロセスが同じキーで投稿中の場合は idempotency_in_progress を返す。
+// 投稿前にキーを予約（Reserve）し、投稿に失敗した場合は予約を取り消して同じキーで再投稿できるようにする。
+func (c *Client) idempotentPost(ctx context.Context, key, fingerprint string, post func() (*PostResult, error)) (*PostResult, error) {
+	if key == "" || c.idempotency == nil {
+		return post()
+	}
+
+	// キーはワークスペースごとに管理する（auth.test に失敗した場合は共通の領域を使う）
+	team := c.workspaceTeamID(ctx)
+	if team == "" {
+		team = "default"
+	}
+
+	result, err, shared := c.postFlight.do(ctx, team+"/"+key, func() (*PostResult, error) {
+		entry, reserved, err := c.idempotency.Reserve(team, key, fingerprint)
+		if err != nil {
+			// 保存先を使えない場合は重複を防げないが、投稿自体は行う
+			c.logger.Warn("failed to reserve idempotency key", "key", key, "error", err)
+			return post()
+		}
+		if !reserved {
+			if entry.Fingerprint != fingerprint {
+				return nil, apperr.New(apperr.CodeIdempotencyConflict,
+					"idempotency_key は別の内容の投稿で使用済みです: "+key, nil)
+			}
+			if entry.Pending {
+				return nil, apperr.New(apperr.CodeIdempotencyInProgress,
+					"同じ idempotency_key の投稿を別のプロセスが実行中です: "+key, nil)
+			}
+			var prev PostResult
+			if err := json.Unmarshal(entry.Result, &prev); err == nil {
+				prev.Replayed = true
+				return &prev, nil
+			}
+		}
+
+		result, err := post()
+		if err != nil {
+			if reserved {
+				if err := c.idempotency.Release(team, key); err != nil {
+					c.logger.Warn("failed to release idempotency key", "key", key, "error", err)
+				}
+			}
+			return nil, err
+		}
+		// 保存に失敗しても投稿は成功しているため、エラーにはしない
+		if data, err := json.Marshal(result); err == nil {
+			if err := c.idempotency.Put(team, key, cache.IdempotencyEntry{
+				Fingerprint: fingerprint,
+				Result:      data,
+				CreatedAt:   time.Now(),
+			}); err != nil {
+				c.logger.Warn("failed to store idempotency key", "key", key, "error", err)
+			}
+		}
+		return result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 同時に呼び出された側には最初の結果のコピーを返す
+	if shared && !result.Replayed {
+		replayed := *result
+		replayed.Replayed = true
+		return &replayed, nil
+	}
+	return result, nil
+}
@@ -0,0 +1,157 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kai-kou/slack-fast-mcp/internal/cache"
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+)
+
+// newIdempotencyTestClient は chat.postMessage の呼び出し回数を数えるクライアントを作成する。
+func newIdempotencyTestClient(t *testing.T, delay time.Duration) (*Client, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.postMessage": func(w http.ResponseWriter, r *http.Request) {
+			n := calls.Add(1)
+			time.Sleep(delay)
+			jsonResponse(w, map[string]any{"ok": true, "channel": "C01234ABCDE", "ts": fmt.Sprintf("1234567890.%06d", n)})
+		},
+		"/auth.test": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "url": "https://test.slack.com/", "team_id": "T01234ABCDE"})
+		},
+	})
+	client := NewClientWithAPI(api)
+	WithIdempotencyStore(cache.NewIdempotencyStore(t.TempDir(), time.Hour))(client)
+	return client, &calls
+}
+
+// --- S54: 同じ idempotency_key での再送は投稿せず最初の結果を返す ---
+func TestClient_PostMessage_IdempotencyKey(t *testing.T) {
+	client, calls := newIdempotencyTestClient(t, 0)
+	ctx := context.Background()
+	opts := PostOptions{IdempotencyKey: "deploy-42"}
+
+	first, err := client.PostMessage(ctx, "C01234ABCDE", "deployed", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := client.PostMessage(ctx, "C01234ABCDE", "deployed", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("chat.postMessage calls = %d, want 1", calls.Load())
+	}
+	if first.Replayed || !second.Replayed || second.TS != first.TS || second.Permalink != first.Permalink {
+		t.Errorf("first = %+v, second = %+v; want second to replay first", first, second)
+	}
+
+	// 同じキーで異なる内容は idempotency_conflict
+	_, err = client.PostMessage(ctx, "C01234ABCDE", "something else", opts)
+	if appErr, ok := err.(*apperr.AppError); !ok || appErr.Code != apperr.CodeIdempotencyConflict {
+		t.Errorf("error = %v, want idempotency_conflict", err)
+	}
+
+	// スレッド返信は別の内容として扱う
+	_, err = client.PostThread(ctx, "C01234ABCDE", first.TS, "deployed", opts)
+	if appErr, ok := err.(*apperr.AppError); !ok || appErr.Code != apperr.CodeIdempotencyConflict {
+		t.Errorf("PostThread error = %v, want idempotency_conflict", err)
+	}
+
+	// キーなしは毎回投稿する
+	for i := 0; i < 2; i++ {
+		if _, err := client.PostMessage(ctx, "C01234ABCDE", "deployed", PostOptions{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls.Load() != 3 {
+		t.Errorf("chat.postMessage calls = %d, want 3", calls.Load())
+	}
+}
+
+// --- S55: 同じ idempotency_key の同時呼び出しは1回の投稿にまとめる ---
+func TestClient_PostThread_IdempotencyKeyConcurrent(t *testing.T) {
+	client, calls := newIdempotencyTestClient(t, 50*time.Millisecond)
+
+	var wg sync.WaitGroup
+	results := make([]*PostResult, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			r, err := client.PostThread(context.Background(), "C01234ABCDE", "1234567890.123456", "retry me",
+				PostOptions{IdempotencyKey: "reply-1"})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			results[i] = r
+		}(i)
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("chat.postMessage calls = %d, want 1", calls.Load())
+	}
+	replayed := 0
+	for _, r := range results {
+		if r != nil && r.Replayed {
+			replayed++
+		}
+	}
+	if replayed != len(results)-1 {
+		t.Errorf("replayed = %d, want %d", replayed, len(results)-1)
+	}
+}
+
+// --- S60: 別のプロセスが予約中のキーは投稿せず、投稿に失敗した予約は取り消す ---
+func TestClient_PostMessage_IdempotencyKeyAcrossProcesses(t *testing.T) {
+	dir := t.TempDir()
+	var calls atomic.Int32
+	fail := true
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.postMessage": func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if fail {
+				jsonResponse(w, map[string]any{"ok": false, "error": "channel_not_found"})
+				return
+			}
+			jsonResponse(w, map[string]any{"ok": true, "channel": "C01234ABCDE", "ts": "1234567890.000001"})
+		},
+		"/auth.test": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "url": "https://test.slack.com/", "team_id": "T01234ABCDE"})
+		},
+	})
+	client := NewClientWithAPI(api)
+	WithIdempotencyStore(cache.NewIdempotencyStore(dir, time.Hour))(client)
+	ctx := context.Background()
+	opts := PostOptions{IdempotencyKey: "deploy-42"}
+
+	// 別のプロセス（同じディレクトリの別インスタンス）が投稿中
+	other := cache.NewIdempotencyStore(dir, time.Hour)
+	if _, ok, err := other.Reserve("T01234ABCDE", "deploy-42", postFingerprint("C01234ABCDE", "", "deployed", opts)); !ok || err != nil {
+		t.Fatalf("Reserve = %v, %v", ok, err)
+	}
+	_, err := client.PostMessage(ctx, "C01234ABCDE", "deployed", opts)
+	if appErr, ok := err.(*apperr.AppError); !ok || appErr.Code != apperr.CodeIdempotencyInProgress || calls.Load() != 0 {
+		t.Errorf("error = %v, calls = %d; want idempotency_in_progress without posting", err, calls.Load())
+	}
+
+	// 投稿に失敗した場合は予約を取り消すため、同じキーで再投稿できる
+	_ = other.Release("T01234ABCDE", "deploy-42")
+	if _, err := client.PostMessage(ctx, "C01234ABCDE", "deployed", opts); err == nil {
+		t.Fatal("expected channel_not_found")
+	}
+	fail = false
+	result, err := client.PostMessage(ctx, "C01234ABCDE", "deployed", opts)
+	if err != nil || result.Replayed || calls.Load() != 2 {
+		t.Errorf("result = %+v, err = %v, calls = %d; want a fresh post", result, err, calls.Load())
+	}
+}
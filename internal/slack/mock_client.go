@@ -1,34 +1,65 @@
 package slack
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // MockClient はテスト用のSlackClientモック実装。
 // 各メソッドのFunc フィールドにテスト用の関数を設定して使用する。
 type MockClient struct {
-	PostMessageFunc    func(ctx context.Context, channel, message string) (*PostResult, error)
-	PostThreadFunc     func(ctx context.Context, channel, threadTS, message string) (*PostResult, error)
-	GetHistoryFunc     func(ctx context.Context, channel string, opts HistoryOptions) (*HistoryResult, error)
-	AddReactionFunc    func(ctx context.Context, channel, timestamp, reaction string) (*ReactionResult, error)
-	RemoveReactionFunc func(ctx context.Context, channel, timestamp, reaction string) (*ReactionResult, error)
-	ResolveChannelFunc func(ctx context.Context, channel string) (string, error)
+	PostMessageFunc       func(ctx context.Context, channel, message string, opts PostOptions) (*PostResult, error)
+	PostThreadFunc        func(ctx context.Context, channel, threadTS, message string, opts PostOptions) (*PostResult, error)
+	UpdateMessageFunc     func(ctx context.Context, channel, timestamp, message string) (*PostResult, error)
+	DeleteMessageFunc     func(ctx context.Context, channel, timestamp string) (*DeleteResult, error)
+	GetHistoryFunc        func(ctx context.Context, channel string, opts HistoryOptions) (*HistoryResult, error)
+	GetThreadRepliesFunc  func(ctx context.Context, channel, threadTS string, opts ThreadOptions) (*ThreadResult, error)
+	SearchMessagesFunc    func(ctx context.Context, opts SearchOptions) (*SearchResult, error)
+	UploadFileFunc        func(ctx context.Context, channel string, opts UploadOptions) (*UploadResult, error)
+	ScheduleMessageFunc   func(ctx context.Context, channel, message string, postAt time.Time, opts PostOptions) (*ScheduleResult, error)
+	ListScheduledFunc     func(ctx context.Context, opts ListScheduledOptions) (*ListScheduledResult, error)
+	DeleteScheduledFunc   func(ctx context.Context, channel, scheduledMessageID string) (*DeleteScheduledResult, error)
+	AddReactionFunc       func(ctx context.Context, channel, timestamp, reaction string) (*ReactionResult, error)
+	RemoveReactionFunc    func(ctx context.Context, channel, timestamp, reaction string) (*ReactionResult, error)
+	ListChannelsFunc      func(ctx context.Context, opts ListChannelsOptions) (*ListChannelsResult, error)
+	ResolveChannelFunc    func(ctx context.Context, channel string) (string, error)
+	WarmChannelIndexFunc  func(ctx context.Context) (int, error)
+	AuthTestFunc          func(ctx context.Context) (*AuthInfo, error)
+	ChannelMembershipFunc func(ctx context.Context, channel string) (*MembershipResult, error)
 }
 
 // PostMessage calls the mock function.
-func (m *MockClient) PostMessage(ctx context.Context, channel, message string) (*PostResult, error) {
+func (m *MockClient) PostMessage(ctx context.Context, channel, message string, opts PostOptions) (*PostResult, error) {
 	if m.PostMessageFunc != nil {
-		return m.PostMessageFunc(ctx, channel, message)
+		return m.PostMessageFunc(ctx, channel, message, opts)
 	}
 	return &PostResult{}, nil
 }
 
 // PostThread calls the mock function.
-func (m *MockClient) PostThread(ctx context.Context, channel, threadTS, message string) (*PostResult, error) {
+func (m *MockClient) PostThread(ctx context.Context, channel, threadTS, message string, opts PostOptions) (*PostResult, error) {
 	if m.PostThreadFunc != nil {
-		return m.PostThreadFunc(ctx, channel, threadTS, message)
+		return m.PostThreadFunc(ctx, channel, threadTS, message, opts)
 	}
 	return &PostResult{}, nil
 }
 
+// UpdateMessage calls the mock function.
+func (m *MockClient) UpdateMessage(ctx context.Context, channel, timestamp, message string) (*PostResult, error) {
+	if m.UpdateMessageFunc != nil {
+		return m.UpdateMessageFunc(ctx, channel, timestamp, message)
+	}
+	return &PostResult{}, nil
+}
+
+// DeleteMessage calls the mock function.
+func (m *MockClient) DeleteMessage(ctx context.Context, channel, timestamp string) (*DeleteResult, error) {
+	if m.DeleteMessageFunc != nil {
+		return m.DeleteMessageFunc(ctx, channel, timestamp)
+	}
+	return &DeleteResult{}, nil
+}
+
 // GetHistory calls the mock function.
 func (m *MockClient) GetHistory(ctx context.Context, channel string, opts HistoryOptions) (*HistoryResult, error) {
 	if m.GetHistoryFunc != nil {
@@ -37,6 +68,30 @@ func (m *MockClient) GetHistory(ctx context.Context, channel string, opts Histor
 	return &HistoryResult{}, nil
 }
 
+// GetThreadReplies calls the mock function.
+func (m *MockClient) GetThreadReplies(ctx context.Context, channel, threadTS string, opts ThreadOptions) (*ThreadResult, error) {
+	if m.GetThreadRepliesFunc != nil {
+		return m.GetThreadRepliesFunc(ctx, channel, threadTS, opts)
+	}
+	return &ThreadResult{}, nil
+}
+
+// SearchMessages calls the mock function.
+func (m *MockClient) SearchMessages(ctx context.Context, opts SearchOptions) (*SearchResult, error) {
+	if m.SearchMessagesFunc != nil {
+		return m.SearchMessagesFunc(ctx, opts)
+	}
+	return &SearchResult{}, nil
+}
+
+// UploadFile calls the mock function.
+func (m *MockClient) UploadFile(ctx context.Context, channel string, opts UploadOptions) (*UploadResult, error) {
+	if m.UploadFileFunc != nil {
+		return m.UploadFileFunc(ctx, channel, opts)
+	}
+	return &UploadResult{}, nil
+}
+
 // AddReaction calls the mock function.
 func (m *MockClient) AddReaction(ctx context.Context, channel, timestamp, reaction string) (*ReactionResult, error) {
 	if m.AddReactionFunc != nil {
@@ -53,6 +108,38 @@ func (m *MockClient) RemoveReaction(ctx context.Context, channel, timestamp, rea
 	return &ReactionResult{}, nil
 }
 
+// ScheduleMessage calls the mock function.
+func (m *MockClient) ScheduleMessage(ctx context.Context, channel, message string, postAt time.Time, opts PostOptions) (*ScheduleResult, error) {
+	if m.ScheduleMessageFunc != nil {
+		return m.ScheduleMessageFunc(ctx, channel, message, postAt, opts)
+	}
+	return &ScheduleResult{}, nil
+}
+
+// ListScheduledMessages calls the mock function.
+func (m *MockClient) ListScheduledMessages(ctx context.Context, opts ListScheduledOptions) (*ListScheduledResult, error) {
+	if m.ListScheduledFunc != nil {
+		return m.ListScheduledFunc(ctx, opts)
+	}
+	return &ListScheduledResult{}, nil
+}
+
+// DeleteScheduledMessage calls the mock function.
+func (m *MockClient) DeleteScheduledMessage(ctx context.Context, channel, scheduledMessageID string) (*DeleteScheduledResult, error) {
+	if m.DeleteScheduledFunc != nil {
+		return m.DeleteScheduledFunc(ctx, channel, scheduledMessageID)
+	}
+	return &DeleteScheduledResult{}, nil
+}
+
+// ListChannels calls the mock function.
+func (m *MockClient) ListChannels(ctx context.Context, opts ListChannelsOptions) (*ListChannelsResult, error) {
+	if m.ListChannelsFunc != nil {
+		return m.ListChannelsFunc(ctx, opts)
+	}
+	return &ListChannelsResult{}, nil
+}
+
 // ResolveChannel calls the mock function.
 func (m *MockClient) ResolveChannel(ctx context.Context, channel string) (string, error) {
 	if m.ResolveChannelFunc != nil {
@@ -60,3 +147,27 @@ func (m *MockClient) ResolveChannel(ctx context.Context, channel string) (string
 	}
 	return channel, nil
 }
+
+// WarmChannelIndex calls the mock function.
+func (m *MockClient) WarmChannelIndex(ctx context.Context) (int, error) {
+	if m.WarmChannelIndexFunc != nil {
+		return m.WarmChannelIndexFunc(ctx)
+	}
+	return 0, nil
+}
+
+// AuthTest calls the mock function.
+func (m *MockClient) AuthTest(ctx context.Context) (*AuthInfo, error) {
+	if m.AuthTestFunc != nil {
+		return m.AuthTestFunc(ctx)
+	}
+	return &AuthInfo{}, nil
+}
+
+// ChannelMembership calls the mock function.
+func (m *MockClient) ChannelMembership(ctx context.Context, channel string) (*MembershipResult, error) {
+	if m.ChannelMembershipFunc != nil {
+		return m.ChannelMembershipFunc(ctx, channel)
+	}
+	return &MembershipResult{Channel: channel}, nil
+}
@@ -0,0 +1,214 @@
+package slack
+
+import (
+	"context"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit は Slack API メソッド1つ分のクライアント側レート制限。
+type RateLimit struct {
+	// PerMinute は1分あたりの呼び出し回数。0 の場合は制限しない。
+	PerMinute int
+	// Burst は連続して即時に呼び出せる回数。0 の場合は PerMinute/10（最低1）。
+	Burst int
+}
+
+// burst は実効的なバースト数を返す。
+func (r RateLimit) burst() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return max(1, r.PerMinute/10)
+}
+
+// Slack API の Tier 名（WithRateLimits のキーに指定するとその Tier の全メソッドを上書きする）。
+const (
+	Tier1 = "tier1"
+	Tier2 = "tier2"
+	Tier3 = "tier3"
+	Tier4 = "tier4"
+)
+
+// tierLimits は Slack の Tier ごとの1分あたりの上限（https://api.slack.com/apis/rate-limits）。
+var tierLimits = map[string]RateLimit{
+	Tier1: {PerMinute: 1},
+	Tier2: {PerMinute: 20},
+	Tier3: {PerMinute: 50},
+	Tier4: {PerMinute: 100},
+}
+
+// methodTiers は本クライアントが呼び出す API メソッドの Tier。
+// 表にないメソッド（files.uploadURL 等の Web API 以外）は制限しない。
+var methodTiers = map[string]string{
+	"auth.test":                    Tier4,
+	"chat.delete":                  Tier3,
+	"chat.deleteScheduledMessage":  Tier3,
+	"chat.getPermalink":            Tier4,
+	"chat.scheduleMessage":         Tier3,
+	"chat.scheduledMessages.list":  Tier3,
+	"chat.update":                  Tier3,
+	"conversations.history":        Tier3,
+	"conversations.info":           Tier3,
+	"conversations.list":           Tier2,
+	"conversations.open":           Tier3,
+	"conversations.replies":        Tier3,
+	"files.completeUploadExternal": Tier4,
+	"files.getUploadURLExternal":   Tier4,
+	"files.info":                   Tier4,
+	"reactions.add":                Tier3,
+	"reactions.remove":             Tier2,
+	"search.messages":              Tier2,
+	"users.info":                   Tier4,
+	"users.list":                   Tier2,
+	"users.lookupByEmail":          Tier3,
+}
+
+// methodLimits はメソッドごとのデフォルト上限（Tier のデフォルトより優先し、設定の Tier 名の上書きよりは後）。
+var methodLimits = map[string]RateLimit{
+	// チャンネル索引の走査（1ページ200件）は名前解決の待ち時間になるため、Tier 2 の1分分をまとめて呼び出せるようにする。
+	// バースト2件のままでは2ページ目以降が3秒ごとになり、CLI のタイムアウト内に数千チャンネルを読み切れない
+	"conversations.list": {PerMinute: 20, Burst: 20},
+	// chat.postMessage はチャンネルごとに1秒1件（短時間のバーストは許容される）。バケットはチャンネルごとに分ける（withChannelRetry）
+	"chat.postMessage": {PerMinute: 60},
+}
+
+// IsRateLimitKey は key が WithRateLimits のキー（Tier 名または "chat.postMessage" 形式のメソッド名）として有効かどうかを返す。
+func IsRateLimitKey(key string) bool {
+	if _, ok := tierLimits[key]; ok {
+		return true
+	}
+	return strings.Contains(key, ".") && !strings.HasPrefix(key, ".") && !strings.HasSuffix(key, ".")
+}
+
+// WithRateLimits はクライアント側レート制限を設定する。
+// overrides のキーはメソッド名（例: "conversations.history"）または Tier 名（Tier1〜Tier4）で、
+// メソッド名 > Tier 名 > デフォルト値 の優先順位で適用する。
+func WithRateLimits(overrides map[string]RateLimit) ClientOption {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(overrides)
+	}
+}
+
+// rateLimiter は API メソッド（chat.postMessage はメソッドとチャンネルの組）ごとのトークンバケットで呼び出しを平準化する。
+type rateLimiter struct {
+	mu        sync.Mutex
+	overrides map[string]RateLimit
+	buckets   map[string]*tokenBucket // キーは bucketKey。nil の値は「制限しない」
+	now       func() time.Time
+}
+
+func newRateLimiter(overrides map[string]RateLimit) *rateLimiter {
+	return &rateLimiter{
+		overrides: overrides,
+		buckets:   make(map[string]*tokenBucket),
+		now:       time.Now,
+	}
+}
+
+// limitFor は method の上限を返す。ok が false の場合は制限しない。
+// 設定のメソッド名 > 設定の Tier 名 > methodLimits > Tier のデフォルト の優先順位で決める。
+func (l *rateLimiter) limitFor(method string) (RateLimit, bool) {
+	if limit, ok := l.overrides[method]; ok {
+		return limit, limit.PerMinute > 0
+	}
+	tier, known := methodTiers[method]
+	if limit, ok := l.overrides[tier]; known && ok {
+		return limit, limit.PerMinute > 0
+	}
+	if limit, ok := methodLimits[method]; ok {
+		return limit, limit.PerMinute > 0
+	}
+	if !known {
+		return RateLimit{}, false
+	}
+	limit := tierLimits[tier]
+	return limit, limit.PerMinute > 0
+}
+
+// bucketKey は method のバケットのキーを返す。channel を指定した場合はチャンネルごとに別のバケットにする。
+func bucketKey(method, channel string) string {
+	if channel == "" {
+		return method
+	}
+	return method + "/" + channel
+}
+
+// wait は method のトークンを1つ取得するまで待機し、待機した時間を返す。
+// channel を指定した場合はチャンネルごとのバケットから取得する（上限は method の設定を使う）。
+// ctx がキャンセルされた場合は取得を取り消して ctx のエラーを返す。l が nil の場合は待機しない。
+func (l *rateLimiter) wait(ctx context.Context, method, channel string) (time.Duration, error) {
+	if l == nil {
+		return 0, nil
+	}
+
+	key := bucketKey(method, channel)
+	l.mu.Lock()
+	b, ok := l.buckets[key]
+	if !ok {
+		if limit, limited := l.limitFor(method); limited {
+			b = newTokenBucket(limit, l.now())
+		}
+		l.buckets[key] = b
+	}
+	if b == nil {
+		l.mu.Unlock()
+		return 0, nil
+	}
+	delay := b.reserve(l.now())
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return 0, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		b.cancel()
+		l.mu.Unlock()
+		return 0, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
+
+// tokenBucket は1分あたり PerMinute 個のトークンを補充し、最大 Burst 個まで蓄えるバケット。
+// トークンが不足している場合も予約として負の残量を許し、補充までの待ち時間を返す。
+type tokenBucket struct {
+	rate   float64 // 1秒あたりの補充数
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	burst := float64(limit.burst())
+	return &tokenBucket{
+		rate:   float64(limit.PerMinute) / 60,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// reserve はトークンを1つ予約し、使用可能になるまでの待ち時間を返す。
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel は reserve で予約したトークンを返却する。
+func (b *tokenBucket) cancel() {
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,168 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// --- S51: トークンバケットはバースト分を即時に通し、以降は補充間隔で待たせる ---
+func TestTokenBucket_Reserve(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := newTokenBucket(RateLimit{PerMinute: 60, Burst: 2}, now)
+
+	for i, want := range []time.Duration{0, 0, time.Second, 2 * time.Second} {
+		if got := b.reserve(now); got != want {
+			t.Errorf("reserve #%d = %v, want %v", i+1, got, want)
+		}
+	}
+
+	// 予約分を取り消し、十分に時間が経てばバースト分まで補充される（それ以上は蓄えない）
+	b.cancel()
+	b.cancel()
+	now = now.Add(time.Minute)
+	for i := 0; i < 2; i++ {
+		if got := b.reserve(now); got != 0 {
+			t.Errorf("reserve after refill #%d = %v, want 0", i+1, got)
+		}
+	}
+	if got := b.reserve(now); got != time.Second {
+		t.Errorf("reserve beyond burst = %v, want 1s", got)
+	}
+}
+
+func TestRateLimiter_LimitFor(t *testing.T) {
+	l := newRateLimiter(map[string]RateLimit{
+		"conversations.history": {PerMinute: 30, Burst: 3},
+		Tier2:                   {PerMinute: 10},
+		"search.messages":       {PerMinute: 0},
+	})
+
+	tests := []struct {
+		method  string
+		want    RateLimit
+		limited bool
+	}{
+		{"conversations.history", RateLimit{PerMinute: 30, Burst: 3}, true}, // メソッド名の上書き
+		{"conversations.list", RateLimit{PerMinute: 10}, true},              // Tier の上書き
+		{"conversations.replies", RateLimit{PerMinute: 50}, true},           // Tier3 のデフォルト
+		{"chat.postMessage", RateLimit{PerMinute: 60}, true},                // Special のデフォルト
+		{"search.messages", RateLimit{}, false},                             // 0 は制限しない
+		{"files.uploadURL", RateLimit{}, false},                             // 表にないメソッド
+	}
+	for _, tt := range tests {
+		got, limited := l.limitFor(tt.method)
+		if got != tt.want || limited != tt.limited {
+			t.Errorf("limitFor(%q) = %+v, %v; want %+v, %v", tt.method, got, limited, tt.want, tt.limited)
+		}
+	}
+
+	if !IsRateLimitKey("tier3") || !IsRateLimitKey("conversations.history") || IsRateLimitKey("history") || IsRateLimitKey("tier5") {
+		t.Error("IsRateLimitKey returned unexpected result")
+	}
+}
+
+// --- S52: 上限を超える呼び出しは Slack に送る前に待機する ---
+func TestClient_RateLimiter_Throttles(t *testing.T) {
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/reactions.add": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true})
+		},
+	})
+	client := NewClientWithAPI(api)
+	WithRateLimits(map[string]RateLimit{"reactions.add": {PerMinute: 600, Burst: 1}})(client)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.AddReaction(context.Background(), "C01234ABCDE", "1234567890.123456", "thumbsup"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// 1件目は即時、2・3件目はそれぞれ 100ms 待つ
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("elapsed = %v, want >= 200ms (throttled)", elapsed)
+	}
+}
+
+// --- S53: 待機中に ctx がキャンセルされた場合は予約を取り消してエラーを返す ---
+func TestRateLimiter_WaitCanceled(t *testing.T) {
+	l := newRateLimiter(map[string]RateLimit{"chat.update": {PerMinute: 1, Burst: 1}})
+	if _, err := l.wait(context.Background(), "chat.update", ""); err != nil {
+		t.Fatalf("first wait error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.wait(ctx, "chat.update", ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait error = %v, want DeadlineExceeded", err)
+	}
+	// 取り消した予約はバケットに返却されている
+	if b := l.buckets["chat.update"]; b.tokens < -0.01 {
+		t.Errorf("tokens = %v, want reservation refunded", b.tokens)
+	}
+}
+
+// --- S61: デフォルトの上限でも数千チャンネルの初回走査が CLI のタイムアウト内に終わり、途中で失敗しても読んだページは使う ---
+func TestClient_ResolveChannel_ColdScanWithinDeadline(t *testing.T) {
+	const pages, perPage = 15, 200
+	failAt := 0
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.list": func(w http.ResponseWriter, r *http.Request) {
+			page, _ := strconv.Atoi(r.FormValue("cursor"))
+			if failAt > 0 && page == failAt {
+				jsonResponse(w, map[string]any{"ok": false, "error": "invalid_auth"})
+				return
+			}
+			channels := make([]map[string]any, 0, perPage)
+			for i := page * perPage; i < (page+1)*perPage; i++ {
+				channels = append(channels, map[string]any{"id": fmt.Sprintf("C%010d", i), "name": fmt.Sprintf("ch-%d", i)})
+			}
+			next := ""
+			if page+1 < pages {
+				next = strconv.Itoa(page + 1)
+			}
+			jsonResponse(w, map[string]any{"ok": true, "channels": channels, "response_metadata": map[string]any{"next_cursor": next}})
+		},
+	})
+
+	client := NewClientWithAPI(api)
+	WithRateLimits(nil)(client)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	id, err := client.ResolveChannel(ctx, "ch-2999")
+	if err != nil || id != "C0000002999" {
+		t.Fatalf("ResolveChannel() = %q, %v, want C0000002999", id, err)
+	}
+
+	// 8ページ目で失敗しても、読み終えたページのチャンネルは解決できる
+	failAt = 8
+	client = NewClientWithAPI(api)
+	WithRateLimits(nil)(client)
+	if id, err := client.ResolveChannel(ctx, "ch-1599"); err != nil || id != "C0000001599" {
+		t.Errorf("ResolveChannel() after partial scan = %q, %v, want C0000001599", id, err)
+	}
+	if _, err := client.ResolveChannel(ctx, "ch-1600"); err == nil {
+		t.Error("expected error for channel on the failed page")
+	}
+}
+
+// --- S62: chat.postMessage の上限はチャンネルごとに数える ---
+func TestRateLimiter_PerChannel(t *testing.T) {
+	l := newRateLimiter(map[string]RateLimit{"chat.postMessage": {PerMinute: 60, Burst: 1}})
+	for _, channel := range []string{"C01234ABCDE", "C09876ZYXWV"} {
+		if waited, err := l.wait(context.Background(), "chat.postMessage", channel); err != nil || waited != 0 {
+			t.Fatalf("wait(%s) = %v, %v, want no wait", channel, waited, err)
+		}
+	}
+
+	// 同じチャンネルへの2件目は補充まで待つ
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.wait(ctx, "chat.postMessage", "C01234ABCDE"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second wait error = %v, want DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,280 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackapi "github.com/slack-go/slack"
+)
+
+// リトライ対象のエラー分類（RetryPolicy.RetryOn に指定する）。
+const (
+	// RetryRateLimited はレート制限（HTTP 429）。Retry-After ヘッダがあればその時間待つ。
+	RetryRateLimited = "rate_limited"
+	// RetryServerError は Slack 側の 5xx 応答や internal_error 等の一時的なエラー。
+	RetryServerError = "server_error"
+	// RetryNetwork は接続リセット・タイムアウト等の通信エラー。
+	RetryNetwork = "network"
+)
+
+// RetryClasses はリトライ対象に指定できるエラー分類の一覧。
+var RetryClasses = []string{RetryRateLimited, RetryServerError, RetryNetwork}
+
+// RetryPolicy は一時的なエラーのリトライ方針。
+type RetryPolicy struct {
+	// MaxAttempts は初回を含む最大試行回数（1 でリトライしない）。
+	MaxAttempts int
+	// BaseBackoff は1回目のリトライ前の待ち時間。以降は2倍ずつ増える。
+	BaseBackoff time.Duration
+	// MaxBackoff は待ち時間の上限（Retry-After の指定にも適用する）。
+	MaxBackoff time.Duration
+	// Jitter は待ち時間に加える揺らぎの割合（0〜1）。0.2 なら ±20%。
+	Jitter float64
+	// RetryOn はリトライするエラー分類（RetryRateLimited / RetryServerError / RetryNetwork）。
+	RetryOn []string
+}
+
+// DefaultRetryPolicy はデフォルトのリトライ方針を返す（最大4回試行、1s から指数バックオフ）。
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: time.Second,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		RetryOn:     append([]string(nil), RetryClasses...),
+	}
+}
+
+// WithRetryPolicy はリトライ方針を設定する。
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if p.MaxAttempts < 1 {
+			p.MaxAttempts = 1
+		}
+		c.retry = p
+	}
+}
+
+// retries は分類 class がリトライ対象かどうかを返す。
+func (p RetryPolicy) retries(class string) bool {
+	for _, c := range p.RetryOn {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff は attempt 回目（0 始まり）のリトライ前の待ち時間を返す。
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := time.Duration(float64(p.BaseBackoff) * math.Pow(2, float64(attempt)))
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d = time.Duration(float64(d) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	return d
+}
+
+// retryClass はエラーの分類を返す。リトライ対象外のエラーは空文字を返す。
+func retryClass(err error) string {
+	var rateLimited *slackapi.RateLimitedError
+	if errors.As(err, &rateLimited) {
+		return RetryRateLimited
+	}
+
+	var status slackapi.StatusCodeError
+	if errors.As(err, &status) {
+		switch {
+		case status.Code == http.StatusTooManyRequests: // Retry-After ヘッダなしの 429
+			return RetryRateLimited
+		case status.Code >= 500:
+			return RetryServerError
+		}
+		return ""
+	}
+	var slackErr slackapi.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		switch slackErr.Err {
+		case "internal_error", "fatal_error", "service_unavailable", "request_timeout":
+			return RetryServerError
+		}
+		return ""
+	}
+
+	// ctx のキャンセル・期限切れはリトライしない
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ""
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return RetryNetwork
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return RetryNetwork
+	}
+	return ""
+}
+
+// nonIdempotentWrites は再実行すると二重に投稿・予約されうる書き込みメソッド。
+// 5xx や通信エラーでは Slack 側で処理済みのまま応答だけが失われた可能性があるため、
+// 未実行が確実なレート制限以外はリトライしない。idempotency_key はこのマシン上での再送を防ぐだけで
+// Slack 側では重複を排除しないため、キー付きの投稿も同じ扱いにする。
+var nonIdempotentWrites = map[string]bool{
+	"chat.postMessage":             true,
+	"chat.update":                  true,
+	"chat.delete":                  true,
+	"chat.scheduleMessage":         true,
+	"files.completeUploadExternal": true,
+}
+
+// retrySafe は method を分類 class のエラーで再実行してよいかを返す。
+func retrySafe(method, class string) bool {
+	return class == RetryRateLimited || !nonIdempotentWrites[method]
+}
+
+// withRetry はリトライ方針に従って一時的なエラー時に fn を再実行する。
+// method（例: "chat.postMessage"）はログ出力・リトライ回数の集計と、書き込みのリトライ可否の判定に使う。
+func (c *Client) withRetry(ctx context.Context, method string, fn func() error) error {
+	return c.withChannelRetry(ctx, method, "", fn)
+}
+
+// withChannelRetry は withRetry と同じだが、クライアント側のレート制限をチャンネル channel ごとに数える
+// （chat.postMessage のようにチャンネル単位で上限が決まるメソッドに使う）。
+func (c *Client) withChannelRetry(ctx context.Context, method, channel string, fn func() error) error {
+	policy := c.retry
+	for attempt := 0; ; attempt++ {
+		// Slack 側のレート制限に達する前に、メソッドごとの上限に合わせて呼び出しを平準化する
+		waited, err := c.limiter.wait(ctx, method, channel)
+		if err != nil {
+			return err
+		}
+		if waited > 0 {
+			c.logger.Debug("slack api throttled", "method", method, "wait", waited)
+		}
+
+		start := time.Now()
+		err = fn()
+		if err == nil {
+			c.logger.Debug("slack api call", "method", method, "attempt", attempt+1, "duration", time.Since(start))
+			return nil
+		}
+
+		class := retryClass(err)
+		if class == "" || !policy.retries(class) || !retrySafe(method, class) {
+			c.logger.Debug("slack api error", "method", method, "duration", time.Since(start), "error", err)
+			return err
+		}
+
+		if attempt+1 >= policy.MaxAttempts {
+			c.logger.Warn("slack api retries exhausted", "method", method, "class", class, "attempts", attempt+1, "error", err)
+			if class == RetryRateLimited {
+				return apperr.New(apperr.CodeRateLimited, "レート制限に到達しました", err)
+			}
+			return err
+		}
+
+		// レート制限は Retry-After ヘッダに従う
+		wait := policy.backoff(attempt)
+		var rateLimited *slackapi.RateLimitedError
+		if errors.As(err, &rateLimited) && rateLimited.RetryAfter > 0 {
+			wait = rateLimited.RetryAfter
+			if policy.MaxBackoff > 0 && wait > policy.MaxBackoff {
+				wait = policy.MaxBackoff
+			}
+		}
+		c.logger.Warn("slack api transient error; retrying", "method", method, "class", class,
+			"attempt", attempt+1, "wait", wait, "error", err)
+		retryStatsFrom(ctx).add(method)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// RetryStats は1回の操作（ツール呼び出し・CLI コマンド）中に発生したリトライ回数を API メソッドごとに集計する。
+type RetryStats struct {
+	mu       sync.Mutex
+	byMethod map[string]int
+}
+
+// retryStatsKey は RetryStats を context に格納するキー。
+type retryStatsKey struct{}
+
+// WithRetryStats はリトライ回数を集計する RetryStats を ctx に設定する。
+// 返された ctx を Client のメソッドに渡すと、その呼び出し中のリトライが記録される。
+func WithRetryStats(ctx context.Context) (context.Context, *RetryStats) {
+	stats := &RetryStats{byMethod: make(map[string]int)}
+	return context.WithValue(ctx, retryStatsKey{}, stats), stats
+}
+
+// retryStatsFrom は ctx の RetryStats を返す（未設定の場合は nil）。
+func retryStatsFrom(ctx context.Context) *RetryStats {
+	stats, _ := ctx.Value(retryStatsKey{}).(*RetryStats)
+	return stats
+}
+
+// add は method のリトライ回数を1増やす。nil の場合は何もしない。
+func (s *RetryStats) add(method string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.byMethod[method]++
+	s.mu.Unlock()
+}
+
+// Total はリトライの合計回数を返す。
+func (s *RetryStats) Total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := 0
+	for _, n := range s.byMethod {
+		total += n
+	}
+	return total
+}
+
+// ByMethod は API メソッドごとのリトライ回数のコピーを返す。
+func (s *RetryStats) ByMethod() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]int, len(s.byMethod))
+	for m, n := range s.byMethod {
+		out[m] = n
+	}
+	return out
+}
+
+// String は "chat.postMessage×2, users.info×1" の形式でリトライ回数を返す（メソッド名順）。
+func (s *RetryStats) String() string {
+	byMethod := s.ByMethod()
+	methods := make([]string, 0, len(byMethod))
+	for m := range byMethod {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+
+	parts := make([]string, 0, len(methods))
+	for _, m := range methods {
+		parts = append(parts, fmt.Sprintf("%s×%d", m, byMethod[m]))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,168 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackapi "github.com/slack-go/slack"
+)
+
+// maxScheduleAhead は予約投稿で指定できる最も先の時刻（Slack API の制限は120日）。
+const maxScheduleAhead = 120 * 24 * time.Hour
+
+// ParsePostAt は予約時刻の指定を解釈する。
+// Unix 秒（"1767225600"）、RFC3339（"2026-01-01T09:00:00+09:00"）、
+// now からの相対時間（"in 2h"、"+30m"、"1h30m"、"2d"）を受け付ける。
+func ParsePostAt(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, apperr.New(apperr.CodeInvalidPostAt, "予約時刻が指定されていません", nil)
+	}
+
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	rel := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(s, "in "), "+"))
+	if d, err := parseRelativeDuration(rel); err == nil {
+		if d <= 0 {
+			return time.Time{}, apperr.New(apperr.CodeInvalidPostAt,
+				fmt.Sprintf("予約時刻は未来を指定してください: %s", s), nil)
+		}
+		return now.Add(d), nil
+	}
+
+	return time.Time{}, apperr.New(apperr.CodeInvalidPostAt,
+		fmt.Sprintf("予約時刻を解釈できません: %s", s), nil)
+}
+
+// ScheduleMessage は指定時刻にメッセージを投稿するよう予約する（chat.scheduleMessage）。
+func (c *Client) ScheduleMessage(ctx context.Context, channel, message string, postAt time.Time, opts PostOptions) (*ScheduleResult, error) {
+	// Slack 側でも検証されるが、チャンネル解決の前に分かりやすいエラーを返す
+	if now := time.Now(); !postAt.After(now) {
+		return nil, apperr.New(apperr.CodeInvalidPostAt,
+			fmt.Sprintf("予約時刻が過去です: %s", postAt.Format(time.RFC3339)), nil)
+	} else if postAt.Sub(now) > maxScheduleAhead {
+		return nil, apperr.New(apperr.CodeInvalidPostAt,
+			fmt.Sprintf("予約時刻は120日以内で指定してください: %s", postAt.Format(time.RFC3339)), nil)
+	}
+
+	msgOpts, err := messageOptions(message, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	channelID, err := c.resolveChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	var respChannel, scheduledID string
+	err = c.withRetry(ctx, "chat.scheduleMessage", func() error {
+		var e error
+		respChannel, scheduledID, e = c.api.ScheduleMessageContext(ctx, channelID,
+			strconv.FormatInt(postAt.Unix(), 10), msgOpts...)
+		return e
+	})
+	if err != nil {
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
+	}
+
+	return &ScheduleResult{
+		Channel:            respChannel,
+		ChannelName:        c.getChannelName(channel, channelID),
+		ScheduledMessageID: scheduledID,
+		PostAt:             postAt.Unix(),
+		Message:            message,
+	}, nil
+}
+
+// ListScheduledMessages は送信待ちの予約投稿一覧を取得する（chat.scheduledMessages.list）。
+func (c *Client) ListScheduledMessages(ctx context.Context, opts ListScheduledOptions) (*ListScheduledResult, error) {
+	// limit のバリデーション
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 1000 {
+		limit = 1000
+	}
+
+	params := &slackapi.GetScheduledMessagesParameters{
+		Limit:  limit,
+		Cursor: opts.Cursor,
+	}
+	if opts.Channel != "" {
+		channelID, err := c.resolveChannel(ctx, opts.Channel)
+		if err != nil {
+			return nil, err
+		}
+		params.Channel = channelID
+	}
+
+	var msgs []slackapi.ScheduledMessage
+	var nextCursor string
+	err := c.withRetry(ctx, "chat.scheduledMessages.list", func() error {
+		var e error
+		msgs, nextCursor, e = c.api.GetScheduledMessagesContext(ctx, params)
+		return e
+	})
+	if err != nil {
+		err = classifySlackError(err)
+		if opts.Channel != "" {
+			err = c.invalidateOnChannelNotFound(ctx, opts.Channel, err)
+		}
+		return nil, err
+	}
+
+	messages := make([]ScheduledMessage, 0, len(msgs))
+	for _, m := range msgs {
+		messages = append(messages, ScheduledMessage{
+			ID:          m.ID,
+			Channel:     m.Channel,
+			ChannelName: c.getChannelName(m.Channel, m.Channel),
+			PostAt:      int64(m.PostAt),
+			DateCreated: int64(m.DateCreated),
+			Text:        m.Text,
+		})
+	}
+
+	return &ListScheduledResult{
+		Messages:   messages,
+		HasMore:    nextCursor != "",
+		NextCursor: nextCursor,
+		Count:      len(messages),
+	}, nil
+}
+
+// DeleteScheduledMessage は送信前の予約投稿を取り消す（chat.deleteScheduledMessage）。
+func (c *Client) DeleteScheduledMessage(ctx context.Context, channel, scheduledMessageID string) (*DeleteScheduledResult, error) {
+	channelID, err := c.resolveChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.withRetry(ctx, "chat.deleteScheduledMessage", func() error {
+		_, e := c.api.DeleteScheduledMessageContext(ctx, &slackapi.DeleteScheduledMessageParameters{
+			Channel:            channelID,
+			ScheduledMessageID: scheduledMessageID,
+		})
+		return e
+	})
+	if err != nil {
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
+	}
+
+	return &DeleteScheduledResult{
+		Channel:            channelID,
+		ChannelName:        c.getChannelName(channel, channelID),
+		ScheduledMessageID: scheduledMessageID,
+	}, nil
+}
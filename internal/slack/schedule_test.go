@@ -0,0 +1,153 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePostAt(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"1767225600", time.Unix(1767225600, 0)},
+		{"2026-01-02T09:00:00+09:00", time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"in 2h", now.Add(2 * time.Hour)},
+		{"+30m", now.Add(30 * time.Minute)},
+		{"1h30m", now.Add(90 * time.Minute)},
+		{"in 2d", now.Add(48 * time.Hour)},
+		{"1d12h", now.Add(36 * time.Hour)},
+	}
+	for _, tt := range tests {
+		got, err := ParsePostAt(tt.in, now)
+		if err != nil {
+			t.Errorf("ParsePostAt(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParsePostAt(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "tomorrow", "in -2h", "in 0s", "2026-01-02"} {
+		if _, err := ParsePostAt(in, now); err == nil || !strings.Contains(err.Error(), "invalid_post_at") {
+			t.Errorf("ParsePostAt(%q) error = %v, want invalid_post_at", in, err)
+		}
+	}
+}
+
+// --- S41: ScheduleMessage 正常系 ---
+func TestClient_ScheduleMessage_Success(t *testing.T) {
+	postAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	var gotPostAt string
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.scheduleMessage": func(w http.ResponseWriter, r *http.Request) {
+			gotPostAt = r.FormValue("post_at")
+			jsonResponse(w, map[string]any{
+				"ok":                   true,
+				"channel":              "C01234ABCDE",
+				"scheduled_message_id": "Q1298393284",
+				"post_at":              postAt.Unix(),
+			})
+		},
+	})
+	client := NewClientWithAPI(api)
+
+	result, err := client.ScheduleMessage(context.Background(), "C01234ABCDE", "deploy window opens", postAt, PostOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPostAt != strconv.FormatInt(postAt.Unix(), 10) {
+		t.Errorf("post_at = %q, want %d", gotPostAt, postAt.Unix())
+	}
+	if result.ScheduledMessageID != "Q1298393284" || result.Channel != "C01234ABCDE" || result.PostAt != postAt.Unix() {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+// --- S42: ScheduleMessage 過去・120日超の時刻は API を呼ばずにエラー ---
+func TestClient_ScheduleMessage_InvalidTime(t *testing.T) {
+	calls := 0
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.scheduleMessage": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			jsonResponse(w, map[string]any{"ok": false, "error": "time_in_past"})
+		},
+	})
+	client := NewClientWithAPI(api)
+
+	for _, postAt := range []time.Time{time.Now().Add(-time.Minute), time.Now().Add(121 * 24 * time.Hour)} {
+		_, err := client.ScheduleMessage(context.Background(), "C01234ABCDE", "hi", postAt, PostOptions{})
+		if err == nil || !strings.Contains(err.Error(), "invalid_post_at") {
+			t.Errorf("postAt=%v: error = %v, want invalid_post_at", postAt, err)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("chat.scheduleMessage calls = %d, want 0", calls)
+	}
+}
+
+// --- S43: ListScheduledMessages 正常系（チャンネル絞り込みとページング） ---
+func TestClient_ListScheduledMessages(t *testing.T) {
+	var gotChannel, gotLimit string
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.scheduledMessages.list": func(w http.ResponseWriter, r *http.Request) {
+			gotChannel = r.FormValue("channel")
+			gotLimit = r.FormValue("limit")
+			jsonResponse(w, map[string]any{
+				"ok": true,
+				"scheduled_messages": []map[string]any{
+					{"id": "Q1", "channel_id": "C01234ABCDE", "post_at": 1767225600, "date_created": 1767139200, "text": "deploy window opens"},
+				},
+				"response_metadata": map[string]any{"next_cursor": "next"},
+			})
+		},
+	})
+	client := NewClientWithAPI(api)
+
+	result, err := client.ListScheduledMessages(context.Background(), ListScheduledOptions{Channel: "C01234ABCDE", Limit: 5000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotChannel != "C01234ABCDE" || gotLimit != "1000" {
+		t.Errorf("channel=%q limit=%q, want C01234ABCDE and clamped limit 1000", gotChannel, gotLimit)
+	}
+	if result.Count != 1 || !result.HasMore || result.NextCursor != "next" {
+		t.Errorf("result = %+v", result)
+	}
+	if m := result.Messages[0]; m.ID != "Q1" || m.PostAt != 1767225600 || m.Text != "deploy window opens" {
+		t.Errorf("message = %+v", m)
+	}
+}
+
+// --- S44: DeleteScheduledMessage 存在しない ID ---
+func TestClient_DeleteScheduledMessage_NotFound(t *testing.T) {
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/chat.deleteScheduledMessage": func(w http.ResponseWriter, r *http.Request) {
+			if r.FormValue("scheduled_message_id") == "Q1" {
+				jsonResponse(w, map[string]any{"ok": true})
+				return
+			}
+			jsonResponse(w, map[string]any{"ok": false, "error": "invalid_scheduled_message_id"})
+		},
+	})
+	client := NewClientWithAPI(api)
+
+	result, err := client.DeleteScheduledMessage(context.Background(), "C01234ABCDE", "Q1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ScheduledMessageID != "Q1" || result.Channel != "C01234ABCDE" {
+		t.Errorf("result = %+v", result)
+	}
+
+	_, err = client.DeleteScheduledMessage(context.Background(), "C01234ABCDE", "Q404")
+	if err == nil || !strings.Contains(err.Error(), "scheduled_message_not_found") {
+		t.Errorf("error = %v, want scheduled_message_not_found", err)
+	}
+}
@@ -0,0 +1,162 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackapi "github.com/slack-go/slack"
+)
+
+// ValidateSearchSort は検索の並び順（score / timestamp）と方向（desc / asc）を検証する。空の場合はデフォルト値を使う。
+func ValidateSearchSort(sort, dir string) error {
+	switch sort {
+	case "", "score", "timestamp":
+	default:
+		return apperr.New(apperr.CodeInvalidSort,
+			fmt.Sprintf("並び順が不正です: %s (score / timestamp)", sort), nil)
+	}
+	switch dir {
+	case "", "desc", "asc":
+	default:
+		return apperr.New(apperr.CodeInvalidSort,
+			fmt.Sprintf("並び順の方向が不正です: %s (desc / asc)", dir), nil)
+	}
+	return nil
+}
+
+// SearchMessages はワークスペース全体からメッセージを検索する（search.messages）。
+// Bot トークンでは検索できないため、WithUserToken で設定したユーザートークンを使用する。
+func (c *Client) SearchMessages(ctx context.Context, opts SearchOptions) (*SearchResult, error) {
+	if c.userAPI == nil {
+		return nil, apperr.New(apperr.CodeUserTokenNotConfigured,
+			"メッセージ検索にはユーザートークンが必要です", nil)
+	}
+
+	query := buildSearchQuery(opts)
+	if query == "" {
+		return nil, apperr.New(apperr.CodeNoQuery, "検索クエリが空です", nil)
+	}
+	if err := ValidateSearchSort(opts.Sort, opts.SortDir); err != nil {
+		return nil, err
+	}
+
+	// count / page のバリデーション
+	count := opts.Count
+	if count <= 0 {
+		count = slackapi.DEFAULT_SEARCH_COUNT
+	}
+	if count > 100 {
+		count = 100
+	}
+	page := opts.Page
+	if page <= 0 {
+		page = slackapi.DEFAULT_SEARCH_PAGE
+	}
+	if page > 100 {
+		page = 100
+	}
+
+	params := slackapi.NewSearchParameters()
+	params.Count = count
+	params.Page = page
+	if opts.Sort == "timestamp" {
+		params.Sort = "timestamp"
+	}
+	if opts.SortDir == "asc" {
+		params.SortDirection = "asc"
+	}
+
+	var resp *slackapi.SearchMessages
+	err := c.withRetry(ctx, "search.messages", func() error {
+		var e error
+		resp, e = c.userAPI.SearchMessagesContext(ctx, query, params)
+		return e
+	})
+	if err != nil {
+		return nil, classifySlackError(err)
+	}
+
+	messages := make([]SearchMessage, 0, len(resp.Matches))
+	for _, m := range resp.Matches {
+		messages = append(messages, SearchMessage{
+			HistoryMessage: HistoryMessage{
+				User:      m.User,
+				UserName:  m.Username,
+				Text:      m.Text,
+				TS:        m.Timestamp,
+				ThreadTS:  threadTSFromPermalink(m.Permalink),
+				Permalink: m.Permalink,
+			},
+			Channel:     m.Channel.ID,
+			ChannelName: m.Channel.Name,
+		})
+	}
+
+	return &SearchResult{
+		Query:    query,
+		Messages: messages,
+		Total:    resp.Total,
+		Page:     resp.Paging.Page,
+		Pages:    resp.Paging.Pages,
+		Count:    len(messages),
+	}, nil
+}
+
+// buildSearchQuery は検索語に in: / from: / after: / before: / on: 修飾子を付与する。
+// 例: Query="deploy", Channel="general", User="U01234ABCDE" → "deploy in:#general from:<@U01234ABCDE>"
+func buildSearchQuery(opts SearchOptions) string {
+	var parts []string
+	if q := strings.TrimSpace(opts.Query); q != "" {
+		parts = append(parts, q)
+	}
+	if opts.Channel != "" {
+		if IsChannelID(opts.Channel) {
+			parts = append(parts, "in:<#"+opts.Channel+">")
+		} else {
+			parts = append(parts, "in:#"+strings.TrimPrefix(opts.Channel, "#"))
+		}
+	}
+	if opts.User != "" {
+		if isUserID(opts.User) {
+			parts = append(parts, "from:<@"+opts.User+">")
+		} else {
+			parts = append(parts, "from:@"+strings.TrimPrefix(opts.User, "@"))
+		}
+	}
+	if opts.After != "" {
+		parts = append(parts, "after:"+opts.After)
+	}
+	if opts.Before != "" {
+		parts = append(parts, "before:"+opts.Before)
+	}
+	if opts.On != "" {
+		parts = append(parts, "on:"+opts.On)
+	}
+	return strings.Join(parts, " ")
+}
+
+// isUserID はユーザーID形式（U/W + 英大文字・数字）かどうかを判定する。
+func isUserID(s string) bool {
+	if len(s) < 9 || (s[0] != 'U' && s[0] != 'W') {
+		return false
+	}
+	for _, r := range s[1:] {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// threadTSFromPermalink はスレッド返信のパーマリンクから thread_ts を取り出す。
+// search.messages の結果には thread_ts が含まれないため、パーマリンクのクエリから補完する。
+func threadTSFromPermalink(permalink string) string {
+	u, err := url.Parse(permalink)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("thread_ts")
+}
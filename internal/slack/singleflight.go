@@ -0,0 +1,56 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// errFlightPanicked は実行中の処理が panic した場合に、待機していた呼び出しへ返すエラー。
+var errFlightPanicked = errors.New("singleflight: in-flight call panicked")
+
+// flightGroup は同じキーの処理を同時に1回だけ実行する（golang.org/x/sync/singleflight の最小実装）。
+// 実行中に同じキーで呼び出されたゴルーチンは、最初の呼び出しの完了を待って同じ結果を受け取る。
+type flightGroup[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall[T]
+}
+
+type flightCall[T any] struct {
+	done chan struct{} // fn の完了（panic を含む）で close する
+	val  T
+	err  error
+}
+
+// do は key の処理が実行中であればその結果を待ち、なければ fn を実行する。
+// 待機中に ctx がキャンセル・期限切れになった場合は ctx のエラーを返す（実行中の処理は続行する）。
+// shared は他の呼び出しの結果を共有した（または待機した）場合に true になる。
+func (g *flightGroup[T]) do(ctx context.Context, key string, fn func() (T, error)) (val T, err error, shared bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall[T])
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.val, call.err, true
+		case <-ctx.Done():
+			return val, ctx.Err(), true
+		}
+	}
+	// fn が panic した場合も待機側が errFlightPanicked を受け取れるよう、先にエラーを設定しておく
+	call := &flightCall[T]{done: make(chan struct{}), err: errFlightPanicked}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.val, call.err = fn()
+	return call.val, call.err, false
+}
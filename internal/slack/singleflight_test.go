@@ -0,0 +1,168 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightGroup_Do(t *testing.T) {
+	var g flightGroup[int]
+	var calls atomic.Int64
+	release := make(chan struct{})
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	var sharedCount atomic.Int64
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err, shared := g.do(context.Background(), "key", func() (int, error) {
+				calls.Add(1)
+				<-release
+				return 42, nil
+			})
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if shared {
+				sharedCount.Add(1)
+			}
+			results[i] = v
+		}(i)
+	}
+
+	// 全員が待機に入るまで待ってから解放する
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn calls = %d, want 1", got)
+	}
+	if got := sharedCount.Load(); got != n-1 {
+		t.Errorf("shared = %d, want %d", got, n-1)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("results[%d] = %d, want 42", i, v)
+		}
+	}
+
+	// 完了後は再度実行される（結果をキャッシュしない）
+	wantErr := errors.New("boom")
+	if _, err, _ := g.do(context.Background(), "key", func() (int, error) { return 0, wantErr }); err != wantErr {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+}
+
+// 待機側は自身の ctx で待機をやめられ、fn が panic しても後続の呼び出しが詰まらない。
+func TestFlightGroup_Do_CancelAndPanic(t *testing.T) {
+	var g flightGroup[int]
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go g.do(context.Background(), "key", func() (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err, shared := g.do(ctx, "key", func() (int, error) { return 2, nil }); !errors.Is(err, context.DeadlineExceeded) || !shared {
+		t.Errorf("waiter err = %v, shared = %v; want deadline exceeded while sharing", err, shared)
+	}
+	close(release)
+
+	waiting := make(chan error, 1)
+	panicked := make(chan struct{})
+	go func() {
+		defer func() { recover(); close(panicked) }()
+		g.do(context.Background(), "panic", func() (int, error) {
+			time.Sleep(20 * time.Millisecond)
+			panic("boom")
+		})
+	}()
+	time.Sleep(5 * time.Millisecond)
+	go func() {
+		_, err, _ := g.do(context.Background(), "panic", func() (int, error) { return 0, nil })
+		waiting <- err
+	}()
+	<-panicked
+	select {
+	case err := <-waiting:
+		if err != nil && !errors.Is(err, errFlightPanicked) {
+			t.Errorf("waiter err = %v, want errFlightPanicked", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter deadlocked after panic")
+	}
+	if v, err, _ := g.do(context.Background(), "panic", func() (int, error) { return 3, nil }); v != 3 || err != nil {
+		t.Errorf("after panic = %d, %v; want a fresh call", v, err)
+	}
+}
+
+// --- S38: 同じチャンネル名の同時解決は conversations.list の走査1回にまとまる ---
+func TestClient_ResolveChannel_Concurrent(t *testing.T) {
+	var listCalls, usersCalls atomic.Int64
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/conversations.list": func(w http.ResponseWriter, r *http.Request) {
+			listCalls.Add(1)
+			time.Sleep(20 * time.Millisecond) // 同時呼び出しが重なるように遅延させる
+			jsonResponse(w, map[string]any{
+				"ok": true,
+				"channels": []map[string]any{
+					{"id": "C01234ABCDE", "name": "general"},
+					{"id": "C09876ZZZZZ", "name": "random"},
+				},
+				"response_metadata": map[string]any{"next_cursor": ""},
+			})
+		},
+		"/conversations.history": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "messages": []map[string]any{
+				{"user": "U01234ABCDE", "text": "hi", "ts": "1700000000.000001"},
+			}})
+		},
+		"/users.info": func(w http.ResponseWriter, r *http.Request) {
+			usersCalls.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			jsonResponse(w, map[string]any{"ok": true, "user": map[string]any{"id": "U01234ABCDE", "name": "alice"}})
+		},
+	})
+	client := NewClientWithAPI(api)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			channel := "general"
+			if i%2 == 1 {
+				channel = "#random"
+			}
+			result, err := client.GetHistory(context.Background(), channel, HistoryOptions{})
+			if err != nil {
+				t.Errorf("GetHistory(%s): %v", channel, err)
+				return
+			}
+			if result.ChannelName == "" || result.Messages[0].UserName != "alice" {
+				t.Errorf("result = %+v, want channel name and user name", result)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := listCalls.Load(); got != 1 {
+		t.Errorf("conversations.list calls = %d, want 1", got)
+	}
+	if got := usersCalls.Load(); got != 1 {
+		t.Errorf("users.info calls = %d, want 1", got)
+	}
+}
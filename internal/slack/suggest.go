@@ -0,0 +1,87 @@
+package slack
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxChannelSuggestions は channel_not_found 時に返す修正候補の最大数。
+const maxChannelSuggestions = 3
+
+// suggestChannelNames は name に近いチャンネル名を候補から最大 limit 件選ぶ。
+// 大文字小文字を無視した編集距離が名前の長さに応じたしきい値以下のもの、
+// または前方一致・部分一致するものを候補とし、近い順（同点は名前順）に並べる。
+func suggestChannelNames(name string, candidates []string, limit int) []string {
+	target := strings.ToLower(strings.TrimPrefix(name, "#"))
+	if target == "" || limit <= 0 {
+		return nil
+	}
+
+	// 短い名前ほど厳しく（"dev" → 1, "general" → 2, "engineering-team" → 5）
+	threshold := len(target) / 3
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	type scored struct {
+		name  string
+		score int
+	}
+	var matches []scored
+	for _, cand := range candidates {
+		lc := strings.ToLower(cand)
+		if lc == "" {
+			continue
+		}
+		score := levenshtein(target, lc)
+		switch {
+		case score <= threshold:
+		case strings.HasPrefix(lc, target),
+			len(lc) >= 3 && strings.HasPrefix(target, lc),
+			len(target) >= 3 && strings.Contains(lc, target):
+			// 前方一致・部分一致は1文字違い相当として扱う（"qa" のような短い名前は入力の先頭と一致しても候補にしない）
+			score = threshold
+		default:
+			continue
+		}
+		matches = append(matches, scored{name: cand, score: score})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var out []string
+	for _, m := range matches {
+		if len(out) == limit {
+			break
+		}
+		out = append(out, m.name)
+	}
+	return out
+}
+
+// levenshtein は2つの文字列の編集距離（挿入・削除・置換）を返す。
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
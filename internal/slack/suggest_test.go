@@ -0,0 +1,61 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuggestChannelNames(t *testing.T) {
+	candidates := []string{"general", "random", "dev-backend", "dev-frontend", "design", "engineering", "General-ja", "qa"}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"typo", "genral", []string{"general"}},
+		{"case and hash", "#GENERAL", []string{"general", "General-ja"}},
+		{"prefix", "dev", []string{"dev-backend", "dev-frontend"}},
+		{"substring", "frontend", []string{"dev-frontend"}},
+		{"longer than candidate", "dev-backend-v2", []string{"dev-backend"}},
+		{"short candidate is not a prefix match", "qa-team-alerts", nil},
+		{"transposition", "radnom", []string{"random"}},
+		{"no match", "marketing", nil},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suggestChannelNames(tt.input, candidates, maxChannelSuggestions)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("suggestChannelNames(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestChannelNames_Limit(t *testing.T) {
+	got := suggestChannelNames("proj", []string{"proj-d", "proj-c", "proj-b", "proj-a"}, 3)
+	if strings.Join(got, ",") != "proj-a,proj-b,proj-c" {
+		t.Errorf("suggestChannelNames = %v, want first 3 by name", got)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"general", "general", 0},
+		{"genral", "general", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"開発", "開発部", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package slack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+)
+
+// ParseSince は履歴の取得範囲の時刻（since・until）の指定を過去の時刻として解釈する。
+// now からさかのぼる相対時間（"24h"、"7d"、"1d12h"）、日付（"2026-01-01"、UTC の 0 時）、
+// RFC3339（"2026-01-01T09:00:00+09:00"）、Unix 秒（"1767225600"）を受け付ける。
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	var t time.Time
+	if d, err := parseRelativeDuration(s); err == nil && d > 0 {
+		t = now.Add(-d)
+	} else if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		t = time.Unix(sec, 0)
+	} else if parsed, err := time.Parse(time.RFC3339, s); err == nil {
+		t = parsed
+	} else if parsed, err := time.Parse(time.DateOnly, s); err == nil {
+		t = parsed
+	} else {
+		return time.Time{}, apperr.New(apperr.CodeInvalidSince,
+			fmt.Sprintf("時刻を解釈できません: %s", s), nil)
+	}
+	if t.After(now) {
+		return time.Time{}, apperr.New(apperr.CodeInvalidSince,
+			fmt.Sprintf("過去の時刻を指定してください: %s", s), nil)
+	}
+	return t, nil
+}
+
+// parseRelativeDuration は time.ParseDuration に日単位（"2d"、"1d12h"）を加えた形式を解釈する。
+func parseRelativeDuration(s string) (time.Duration, error) {
+	var days time.Duration
+	if i := strings.Index(s, "d"); i > 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, err
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		if s = s[i+1:]; s == "" {
+			return days, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return days + d, nil
+}
@@ -0,0 +1,56 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// --- S58: ParseSince は相対時間・日付・RFC3339・Unix 秒を過去の時刻として解釈する ---
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 1, 8, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"24h", now.Add(-24 * time.Hour)},
+		{"7d", now.Add(-7 * 24 * time.Hour)},
+		{"1d12h", now.Add(-36 * time.Hour)},
+		{"2026-01-01", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-01-02T09:00:00+09:00", time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"1767225600", time.Unix(1767225600, 0)},
+	}
+	for _, tt := range tests {
+		got, err := ParseSince(tt.in, now)
+		if err != nil {
+			t.Errorf("ParseSince(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "yesterday", "-2h", "2026-02-01"} {
+		if _, err := ParseSince(in, now); err == nil || !strings.Contains(err.Error(), "invalid_since") {
+			t.Errorf("ParseSince(%q) error = %v, want invalid_since", in, err)
+		}
+	}
+}
+
+func TestParseRelativeDuration(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"90m":   90 * time.Minute,
+		"2d":    48 * time.Hour,
+		"1d12h": 36 * time.Hour,
+	} {
+		if got, err := parseRelativeDuration(in); err != nil || got != want {
+			t.Errorf("parseRelativeDuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "d", "xd", "1d2"} {
+		if _, err := parseRelativeDuration(in); err == nil {
+			t.Errorf("parseRelativeDuration(%q) should fail", in)
+		}
+	}
+}
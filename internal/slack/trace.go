@@ -0,0 +1,72 @@
+package slack
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// maxTraceBody はトレースに出力するリクエスト/レスポンスボディの最大バイト数。
+const maxTraceBody = 4096
+
+// traceTransport は Slack API との HTTP 通信を debug レベルで記録する http.RoundTripper。
+// トークンは logging のハンドラでマスキングされる。
+type traceTransport struct {
+	base   http.RoundTripper
+	logger *slog.Logger
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := path.Base(req.URL.Path)
+	t.logger.Debug("slack api request", "method", method, "body", t.requestBody(req))
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.Debug("slack api transport error", "method", method, "duration", time.Since(start), "error", err)
+		return nil, err
+	}
+
+	// ボディを読み取って記録し、呼び出し側が読めるよう差し戻す
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	t.logger.Debug("slack api response", "method", method, "status", resp.StatusCode,
+		"duration", time.Since(start), "body", truncateTrace(body))
+	return resp, nil
+}
+
+// requestBody はフォーム送信のボディを読み取って返し、req.Body を差し戻す。
+// ファイル送信などフォーム以外のボディは内容を出力しない。
+func (t *traceTransport) requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+	if ct := req.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/x-www-form-urlencoded") &&
+		!strings.HasPrefix(ct, "application/json") {
+		return "(" + ct + ")"
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer rc.Close()
+	body, _ := io.ReadAll(io.LimitReader(rc, maxTraceBody+1))
+	return truncateTrace(body)
+}
+
+// truncateTrace はボディを maxTraceBody バイトまでに切り詰める。
+func truncateTrace(body []byte) string {
+	if len(body) > maxTraceBody {
+		return string(body[:maxTraceBody]) + "...(truncated)"
+	}
+	return string(body)
+}
@@ -1,28 +1,75 @@
 // Package slack provides a Slack API client with channel resolution and retry logic.
 package slack
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // SlackClient はSlack API操作のインターフェース。
 // テスト時にモック注入可能にするため、インターフェースとして定義する。
 type SlackClient interface {
 	// PostMessage はチャンネルにメッセージを投稿する。
-	PostMessage(ctx context.Context, channel, message string) (*PostResult, error)
+	PostMessage(ctx context.Context, channel, message string, opts PostOptions) (*PostResult, error)
 
 	// PostThread はスレッドに返信を投稿する。
-	PostThread(ctx context.Context, channel, threadTS, message string) (*PostResult, error)
+	PostThread(ctx context.Context, channel, threadTS, message string, opts PostOptions) (*PostResult, error)
+
+	// UpdateMessage は投稿済みメッセージの本文を更新する。
+	UpdateMessage(ctx context.Context, channel, timestamp, message string) (*PostResult, error)
+
+	// DeleteMessage は投稿済みメッセージを削除する。
+	DeleteMessage(ctx context.Context, channel, timestamp string) (*DeleteResult, error)
 
 	// GetHistory はチャンネルの投稿履歴を取得する。
 	GetHistory(ctx context.Context, channel string, opts HistoryOptions) (*HistoryResult, error)
 
+	// GetThreadReplies はスレッドの返信一覧を取得する。
+	GetThreadReplies(ctx context.Context, channel, threadTS string, opts ThreadOptions) (*ThreadResult, error)
+
+	// SearchMessages はワークスペース全体からメッセージを検索する（ユーザートークンが必要）。
+	SearchMessages(ctx context.Context, opts SearchOptions) (*SearchResult, error)
+
+	// UploadFile はファイルをアップロードしてチャンネル（またはスレッド）に共有する。
+	UploadFile(ctx context.Context, channel string, opts UploadOptions) (*UploadResult, error)
+
+	// ScheduleMessage は指定時刻にメッセージを投稿するよう予約する。
+	ScheduleMessage(ctx context.Context, channel, message string, postAt time.Time, opts PostOptions) (*ScheduleResult, error)
+
+	// ListScheduledMessages は送信待ちの予約投稿一覧を取得する。
+	ListScheduledMessages(ctx context.Context, opts ListScheduledOptions) (*ListScheduledResult, error)
+
+	// DeleteScheduledMessage は送信前の予約投稿を取り消す。
+	DeleteScheduledMessage(ctx context.Context, channel, scheduledMessageID string) (*DeleteScheduledResult, error)
+
 	// AddReaction はメッセージにリアクション（絵文字）を追加する。
 	AddReaction(ctx context.Context, channel, timestamp, reaction string) (*ReactionResult, error)
 
 	// RemoveReaction はメッセージからリアクション（絵文字）を削除する。
 	RemoveReaction(ctx context.Context, channel, timestamp, reaction string) (*ReactionResult, error)
 
+	// ListChannels はワークスペースのチャンネル一覧を取得する。
+	ListChannels(ctx context.Context, opts ListChannelsOptions) (*ListChannelsResult, error)
+
 	// ResolveChannel はチャンネル名をチャンネルIDに解決する。
 	ResolveChannel(ctx context.Context, channel string) (string, error)
+
+	// WarmChannelIndex は全チャンネルの名前 → ID 索引を構築し、登録したチャンネル数を返す。
+	WarmChannelIndex(ctx context.Context) (int, error)
+
+	// AuthTest はトークンを検証し、ワークスペース・Bot の情報と付与されたスコープを返す。
+	AuthTest(ctx context.Context) (*AuthInfo, error)
+
+	// ChannelMembership は Bot がチャンネルに参加しているかどうかを返す。
+	ChannelMembership(ctx context.Context, channel string) (*MembershipResult, error)
+}
+
+// PostOptions はメッセージ投稿のオプション。
+type PostOptions struct {
+	// Blocks は Block Kit ブロックの JSON 配列。指定時は message は通知等のフォールバックテキストになる。
+	Blocks string `json:"blocks,omitempty"`
+	// IdempotencyKey を指定すると、同じキーでの再送時に投稿せず最初の結果を返す（PostMessage / PostThread のみ）。
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // PostResult はメッセージ投稿の結果。
@@ -33,6 +80,15 @@ type PostResult struct {
 	ThreadTS    string `json:"thread_ts,omitempty"`
 	Message     string `json:"message"`
 	Permalink   string `json:"permalink"`
+	// Replayed は idempotency_key により投稿せずに最初の結果を返した場合に true。
+	Replayed bool `json:"replayed,omitempty"`
+}
+
+// DeleteResult はメッセージ削除の結果。
+type DeleteResult struct {
+	Channel     string `json:"channel"`
+	ChannelName string `json:"channel_name"`
+	TS          string `json:"ts"`
 }
 
 // HistoryOptions は履歴取得のオプション。
@@ -40,6 +96,7 @@ type HistoryOptions struct {
 	Limit  int    `json:"limit"`
 	Oldest string `json:"oldest,omitempty"`
 	Latest string `json:"latest,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
 }
 
 // HistoryResult は履歴取得の結果。
@@ -48,6 +105,7 @@ type HistoryResult struct {
 	ChannelName string           `json:"channel_name"`
 	Messages    []HistoryMessage `json:"messages"`
 	HasMore     bool             `json:"has_more"`
+	NextCursor  string           `json:"next_cursor,omitempty"`
 	Count       int              `json:"count"`
 }
 
@@ -62,6 +120,110 @@ type HistoryMessage struct {
 	Permalink  string `json:"permalink"`
 }
 
+// ThreadOptions はスレッド返信取得のオプション。
+type ThreadOptions struct {
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ThreadResult はスレッド返信取得の結果。
+// Messages の先頭はスレッド元メッセージ（最初のページのみ）。
+type ThreadResult struct {
+	Channel     string           `json:"channel"`
+	ChannelName string           `json:"channel_name"`
+	ThreadTS    string           `json:"thread_ts"`
+	Messages    []HistoryMessage `json:"messages"`
+	HasMore     bool             `json:"has_more"`
+	NextCursor  string           `json:"next_cursor,omitempty"`
+	Count       int              `json:"count"`
+}
+
+// SearchOptions はメッセージ検索のオプション。
+// Channel / User / After / Before / On は検索クエリの修飾子（in: / from: / after: 等）として付与する。
+type SearchOptions struct {
+	Query   string `json:"query"`
+	Channel string `json:"channel,omitempty"`
+	User    string `json:"user,omitempty"`
+	After   string `json:"after,omitempty"`    // YYYY-MM-DD
+	Before  string `json:"before,omitempty"`   // YYYY-MM-DD
+	On      string `json:"on,omitempty"`       // YYYY-MM-DD
+	Sort    string `json:"sort,omitempty"`     // score または timestamp
+	SortDir string `json:"sort_dir,omitempty"` // desc または asc
+	Count   int    `json:"count"`
+	Page    int    `json:"page"`
+}
+
+// SearchResult はメッセージ検索の結果。
+type SearchResult struct {
+	Query    string          `json:"query"`
+	Messages []SearchMessage `json:"messages"`
+	Total    int             `json:"total"`
+	Page     int             `json:"page"`
+	Pages    int             `json:"pages"`
+	Count    int             `json:"count"`
+}
+
+// SearchMessage は検索結果の個別メッセージ（HistoryMessage + チャンネル情報）。
+type SearchMessage struct {
+	HistoryMessage
+	Channel     string `json:"channel"`
+	ChannelName string `json:"channel_name"`
+}
+
+// UploadOptions はファイルアップロードのオプション。
+type UploadOptions struct {
+	Filename       string `json:"filename"`
+	Title          string `json:"title,omitempty"`
+	Content        []byte `json:"-"`
+	ThreadTS       string `json:"thread_ts,omitempty"`
+	InitialComment string `json:"initial_comment,omitempty"`
+	SnippetType    string `json:"snippet_type,omitempty"` // 例: text, diff, go
+}
+
+// UploadResult はファイルアップロードの結果。
+type UploadResult struct {
+	Channel     string `json:"channel"`
+	ChannelName string `json:"channel_name"`
+	FileID      string `json:"file_id"`
+	Filename    string `json:"filename"`
+	Title       string `json:"title"`
+	Size        int    `json:"size"`
+	ThreadTS    string `json:"thread_ts,omitempty"`
+	Permalink   string `json:"permalink"`
+}
+
+// ListChannelsOptions はチャンネル一覧取得のオプション。
+type ListChannelsOptions struct {
+	// Types は取得する会話タイプ（public_channel, private_channel, mpim, im）。
+	// 空の場合は public_channel + private_channel（groups:read がなければ public_channel のみ）。
+	Types           []string `json:"types,omitempty"`
+	MemberOnly      bool     `json:"member_only,omitempty"`
+	Prefix          string   `json:"prefix,omitempty"`
+	IncludeArchived bool     `json:"include_archived,omitempty"`
+	Limit           int      `json:"limit"`
+	Cursor          string   `json:"cursor,omitempty"`
+}
+
+// ListChannelsResult はチャンネル一覧取得の結果。
+type ListChannelsResult struct {
+	Channels   []ChannelInfo `json:"channels"`
+	HasMore    bool          `json:"has_more"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	Count      int           `json:"count"`
+}
+
+// ChannelInfo はチャンネル一覧内の個別チャンネル。
+type ChannelInfo struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsPrivate  bool   `json:"is_private"`
+	IsMember   bool   `json:"is_member"`
+	IsArchived bool   `json:"is_archived"`
+	NumMembers int    `json:"num_members"`
+	Topic      string `json:"topic,omitempty"`
+	Purpose    string `json:"purpose,omitempty"`
+}
+
 // ReactionResult はリアクション操作の結果。
 type ReactionResult struct {
 	Channel     string `json:"channel"`
@@ -69,3 +231,67 @@ type ReactionResult struct {
 	Timestamp   string `json:"timestamp"`
 	Reaction    string `json:"reaction"`
 }
+
+// ScheduleResult は予約投稿の結果。
+type ScheduleResult struct {
+	Channel            string `json:"channel"`
+	ChannelName        string `json:"channel_name"`
+	ScheduledMessageID string `json:"scheduled_message_id"`
+	PostAt             int64  `json:"post_at"` // Unix 秒
+	Message            string `json:"message"`
+}
+
+// ListScheduledOptions は予約投稿一覧取得のオプション。
+type ListScheduledOptions struct {
+	// Channel が空の場合は全チャンネルの予約投稿を取得する。
+	Channel string `json:"channel,omitempty"`
+	Limit   int    `json:"limit"`
+	Cursor  string `json:"cursor,omitempty"`
+}
+
+// ListScheduledResult は予約投稿一覧取得の結果。
+type ListScheduledResult struct {
+	Messages   []ScheduledMessage `json:"messages"`
+	HasMore    bool               `json:"has_more"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	Count      int                `json:"count"`
+}
+
+// ScheduledMessage は予約投稿一覧内の個別メッセージ。
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	Channel     string `json:"channel"`
+	ChannelName string `json:"channel_name,omitempty"`
+	PostAt      int64  `json:"post_at"`
+	DateCreated int64  `json:"date_created"`
+	Text        string `json:"text"`
+}
+
+// DeleteScheduledResult は予約投稿の取り消し結果。
+type DeleteScheduledResult struct {
+	Channel            string `json:"channel"`
+	ChannelName        string `json:"channel_name"`
+	ScheduledMessageID string `json:"scheduled_message_id"`
+}
+
+// AuthInfo は auth.test の結果。
+type AuthInfo struct {
+	URL    string `json:"url"`
+	Team   string `json:"team"`
+	TeamID string `json:"team_id"`
+	User   string `json:"user"`
+	UserID string `json:"user_id"`
+	BotID  string `json:"bot_id,omitempty"`
+	// Scopes は Bot トークンに付与された OAuth スコープ（応答ヘッダ X-OAuth-Scopes）。
+	// ヘッダがなく判別できない場合は nil。
+	Scopes []string `json:"scopes"`
+	// UserScopes はユーザートークンに付与された OAuth スコープ。ユーザートークン未設定・判別できない場合は nil。
+	UserScopes []string `json:"user_scopes,omitempty"`
+}
+
+// MembershipResult はチャンネル参加状況の確認結果。
+type MembershipResult struct {
+	Channel     string `json:"channel"`
+	ChannelName string `json:"channel_name"`
+	IsMember    bool   `json:"is_member"`
+}
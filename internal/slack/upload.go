@@ -0,0 +1,103 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	apperr "github.com/kai-kou/slack-fast-mcp/internal/errors"
+	slackapi "github.com/slack-go/slack"
+)
+
+// UploadFile はファイルをアップロードしてチャンネル（またはスレッド）に共有する。
+// files.getUploadURLExternal → アップロードURLへ送信 → files.completeUploadExternal の順に呼び出す。
+func (c *Client) UploadFile(ctx context.Context, channel string, opts UploadOptions) (*UploadResult, error) {
+	if len(opts.Content) == 0 {
+		return nil, apperr.New(apperr.CodeNoFileContent, "アップロードする内容が空です", nil)
+	}
+	if opts.Filename == "" {
+		return nil, apperr.New(apperr.CodeNoFileContent, "ファイル名が指定されていません", nil)
+	}
+
+	channelID, err := c.resolveChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = opts.Filename
+	}
+
+	// 1. アップロードURLの取得
+	var upload *slackapi.GetUploadURLExternalResponse
+	err = c.withRetry(ctx, "files.getUploadURLExternal", func() error {
+		var e error
+		upload, e = c.api.GetUploadURLExternalContext(ctx, slackapi.GetUploadURLExternalParameters{
+			FileName:    opts.Filename,
+			FileSize:    len(opts.Content),
+			SnippetType: opts.SnippetType,
+		})
+		return e
+	})
+	if err != nil {
+		return nil, classifySlackError(err)
+	}
+
+	// 2. ファイル本体の送信
+	err = c.withRetry(ctx, "files.uploadURL", func() error {
+		return c.api.UploadToURL(ctx, slackapi.UploadToURLParameters{
+			UploadURL: upload.UploadURL,
+			Reader:    bytes.NewReader(opts.Content),
+			Filename:  opts.Filename,
+		})
+	})
+	if err != nil {
+		return nil, classifySlackError(err)
+	}
+
+	// 3. アップロード完了とチャンネルへの共有
+	var complete *slackapi.CompleteUploadExternalResponse
+	err = c.withRetry(ctx, "files.completeUploadExternal", func() error {
+		var e error
+		complete, e = c.api.CompleteUploadExternalContext(ctx, slackapi.CompleteUploadExternalParameters{
+			Files:           []slackapi.FileSummary{{ID: upload.FileID, Title: title}},
+			Channel:         channelID,
+			InitialComment:  opts.InitialComment,
+			ThreadTimestamp: opts.ThreadTS,
+		})
+		return e
+	})
+	if err != nil {
+		return nil, c.invalidateOnChannelNotFound(ctx, channel, classifySlackError(err))
+	}
+	if len(complete.Files) != 1 {
+		return nil, apperr.New(apperr.CodeNetworkError,
+			fmt.Sprintf("アップロード結果のファイル数が不正です（%d件）", len(complete.Files)), nil)
+	}
+
+	return &UploadResult{
+		Channel:     channelID,
+		ChannelName: c.getChannelName(channel, channelID),
+		FileID:      complete.Files[0].ID,
+		Filename:    opts.Filename,
+		Title:       complete.Files[0].Title,
+		Size:        len(opts.Content),
+		ThreadTS:    opts.ThreadTS,
+		Permalink:   c.filePermalink(ctx, complete.Files[0].ID),
+	}, nil
+}
+
+// filePermalink はファイルのパーマリンクを files.info で取得する（ベストエフォート）。
+func (c *Client) filePermalink(ctx context.Context, fileID string) string {
+	var file *slackapi.File
+	err := c.withRetry(ctx, "files.info", func() error {
+		var e error
+		file, _, _, e = c.api.GetFileInfoContext(ctx, fileID, 0, 0)
+		return e
+	})
+	if err != nil {
+		return ""
+	}
+	return file.Permalink
+}
@@ -0,0 +1,98 @@
+package slack
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// --- F01: UploadFile 外部アップロードフロー ---
+func TestClient_UploadFile_Success(t *testing.T) {
+	var serverURL, gotFilename, gotLength, gotBody, gotChannel, gotThreadTS, gotComment, gotFiles string
+	srv, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/files.getUploadURLExternal": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			gotFilename = r.FormValue("filename")
+			gotLength = r.FormValue("length")
+			jsonResponse(w, map[string]any{"ok": true, "upload_url": serverURL + "/upload", "file_id": "F01234ABCDE"})
+		},
+		"/upload": func(w http.ResponseWriter, r *http.Request) {
+			file, _, err := r.FormFile("file")
+			if err == nil {
+				b, _ := io.ReadAll(file)
+				gotBody = string(b)
+			}
+			w.WriteHeader(http.StatusOK)
+		},
+		"/files.completeUploadExternal": func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			gotChannel = r.FormValue("channel_id")
+			gotThreadTS = r.FormValue("thread_ts")
+			gotComment = r.FormValue("initial_comment")
+			gotFiles = r.FormValue("files")
+			jsonResponse(w, map[string]any{"ok": true, "files": []map[string]any{{"id": "F01234ABCDE", "title": "Build log"}}})
+		},
+		"/files.info": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "file": map[string]any{
+				"id": "F01234ABCDE", "permalink": "https://test.slack.com/files/U1/F01234ABCDE/build.log",
+			}})
+		},
+	})
+	serverURL = srv.URL
+
+	client := NewClientWithAPI(api)
+	result, err := client.UploadFile(context.Background(), "C01234ABCDE", UploadOptions{
+		Filename:       "build.log",
+		Title:          "Build log",
+		Content:        []byte("line1\nline2\n"),
+		ThreadTS:       "1234567890.123456",
+		InitialComment: "CI failed",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotFilename != "build.log" || gotLength != "12" {
+		t.Errorf("getUploadURLExternal filename/length = %q/%q, want build.log/12", gotFilename, gotLength)
+	}
+	if gotBody != "line1\nline2\n" {
+		t.Errorf("uploaded body = %q, want file content", gotBody)
+	}
+	if gotChannel != "C01234ABCDE" || gotThreadTS != "1234567890.123456" || gotComment != "CI failed" {
+		t.Errorf("complete channel/thread/comment = %q/%q/%q", gotChannel, gotThreadTS, gotComment)
+	}
+	if !strings.Contains(gotFiles, `"title":"Build log"`) {
+		t.Errorf("files = %q, want title", gotFiles)
+	}
+	if result.FileID != "F01234ABCDE" || result.Size != 12 || result.Permalink == "" {
+		t.Errorf("result = %+v, want file id, size and permalink", result)
+	}
+}
+
+// --- F02: UploadFile 空の内容 ---
+func TestClient_UploadFile_Empty(t *testing.T) {
+	client := NewClient("xoxb-test")
+	_, err := client.UploadFile(context.Background(), "C01234ABCDE", UploadOptions{Filename: "empty.txt"})
+	if err == nil || !strings.Contains(err.Error(), "no_file_content") {
+		t.Errorf("error = %v, want no_file_content", err)
+	}
+}
+
+// --- F03: UploadFile スコープ不足 ---
+func TestClient_UploadFile_MissingScope(t *testing.T) {
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/files.getUploadURLExternal": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": false, "error": "missing_scope"})
+		},
+	})
+
+	_, err := NewClientWithAPI(api).UploadFile(context.Background(), "C01234ABCDE", UploadOptions{
+		Filename: "a.txt",
+		Content:  []byte("a"),
+	})
+	if err == nil || !strings.Contains(err.Error(), "missing_scope") {
+		t.Errorf("error = %v, want missing_scope", err)
+	}
+}
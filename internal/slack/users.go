@@ -0,0 +1,224 @@
+package slack
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	slackapi "github.com/slack-go/slack"
+)
+
+// userCacheTTL はユーザー名キャッシュの有効期間。
+// 表示名の変更は頻繁ではないため、MCP Server の長時間稼働でも十分な鮮度を保てる長さにする。
+const userCacheTTL = 30 * time.Minute
+
+// userLookupConcurrency は users.info を並列に呼び出す最大数。
+const userLookupConcurrency = 4
+
+// userCache はユーザーID → ユーザー名のTTL付きキャッシュ。
+type userCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]userCacheEntry
+	now     func() time.Time
+}
+
+type userCacheEntry struct {
+	name    string
+	expires time.Time
+}
+
+func newUserCache(ttl time.Duration) *userCache {
+	return &userCache{
+		ttl:     ttl,
+		entries: make(map[string]userCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// get は有効期限内のユーザー名を返す。
+func (c *userCache) get(id string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[id]
+	if !ok || c.now().After(e.expires) {
+		return "", false
+	}
+	return e.name, true
+}
+
+// set はユーザー名をキャッシュに保存する。
+func (c *userCache) set(id, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[id] = userCacheEntry{name: name, expires: c.now().Add(c.ttl)}
+}
+
+// resolveUserNames はユーザーIDの一覧をユーザー名に解決する（ベストエフォート）。
+// 重複を除いたうえでキャッシュにないIDのみ users.info を呼び出す。
+// 解決できなかったIDは結果に含まれない。
+func (c *Client) resolveUserNames(ctx context.Context, ids []string) map[string]string {
+	names := make(map[string]string, len(ids))
+	var missing []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if name, ok := c.users.get(id); ok {
+			names[id] = name
+			continue
+		}
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return names
+	}
+
+	// ディスクキャッシュを確認
+	if cached := c.cachedUserNames(ctx, missing); len(cached) > 0 {
+		remaining := missing[:0]
+		for _, id := range missing {
+			if name, ok := cached[id]; ok {
+				c.users.set(id, name)
+				names[id] = name
+				continue
+			}
+			remaining = append(remaining, id)
+		}
+		missing = remaining
+		if len(missing) == 0 {
+			return names
+		}
+	}
+
+	fetched := make(map[string]string, len(missing))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, userLookupConcurrency)
+	for _, id := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			// 並行する他の呼び出しと同じユーザーは users.info を1回だけ呼ぶ
+			name, err, _ := c.userFlight.do(ctx, id, func() (string, error) {
+				var user *slackapi.User
+				err := c.withRetry(ctx, "users.info", func() error {
+					var e error
+					user, e = c.api.GetUserInfoContext(ctx, id)
+					return e
+				})
+				if err != nil {
+					return "", err
+				}
+				c.users.set(id, user.Name)
+				return user.Name, nil
+			})
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			names[id] = name
+			fetched[id] = name
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+
+	c.storeUserNames(ctx, fetched)
+	return names
+}
+
+// workspaceRetryInterval は auth.test に失敗した後、再試行せずに空のワークスペース情報を返す期間。
+// パーマリンクの生成でメッセージごとに失敗する auth.test を呼ばないようにする。
+const workspaceRetryInterval = 30 * time.Second
+
+// workspaceInfo は auth.test で取得するワークスペースの情報。
+type workspaceInfo struct {
+	url    string
+	teamID string
+}
+
+// fetchWorkspace は auth.test でワークスペースURLとチームIDを取得する。
+// 成功した結果はクライアントの生存期間中キャッシュし、失敗した場合は workspaceRetryInterval の後に再試行する。
+// auth.test の呼び出し中は workspaceMu を保持せず、同時に呼び出された場合は1回の呼び出しにまとめる。
+func (c *Client) fetchWorkspace(ctx context.Context) workspaceInfo {
+	c.workspaceMu.Lock()
+	if c.workspaceFetched || time.Since(c.workspaceFailedAt) < workspaceRetryInterval {
+		info := c.workspace
+		c.workspaceMu.Unlock()
+		return info
+	}
+	c.workspaceMu.Unlock()
+
+	info, _, _ := c.authFlight.do(ctx, "auth.test", func() (workspaceInfo, error) {
+		var resp *slackapi.AuthTestResponse
+		err := c.withRetry(ctx, "auth.test", func() error {
+			var e error
+			resp, e = c.api.AuthTestContext(ctx)
+			return e
+		})
+
+		c.workspaceMu.Lock()
+		defer c.workspaceMu.Unlock()
+		if err != nil {
+			// ctx のキャンセルで失敗した場合は次回すぐに再試行する
+			if ctx.Err() == nil {
+				c.workspaceFailedAt = time.Now()
+			}
+			return workspaceInfo{}, err
+		}
+		c.workspace = workspaceInfo{url: resp.URL, teamID: resp.TeamID}
+		c.workspaceFetched = true
+		return c.workspace, nil
+	})
+	return info
+}
+
+// workspaceURL は auth.test で取得したワークスペースURL（例: https://example.slack.com/）を返す。
+func (c *Client) workspaceURL(ctx context.Context) string {
+	return c.fetchWorkspace(ctx).url
+}
+
+// workspaceTeamID は auth.test で取得したチームID（例: T01234ABCDE）を返す。
+func (c *Client) workspaceTeamID(ctx context.Context) string {
+	return c.fetchWorkspace(ctx).teamID
+}
+
+// permalink はメッセージのパーマリンクを返す（ベストエフォート）。
+// ワークスペースURLが分かればローカルで組み立て、分からなければ chat.getPermalink を呼ぶ。
+func (c *Client) permalink(ctx context.Context, channelID, ts, threadTS string) string {
+	if base := c.workspaceURL(ctx); base != "" {
+		return buildPermalink(base, channelID, ts, threadTS)
+	}
+
+	var permalink string
+	err := c.withRetry(ctx, "chat.getPermalink", func() error {
+		var e error
+		permalink, e = c.api.GetPermalinkContext(ctx, &slackapi.PermalinkParameters{
+			Channel: channelID,
+			Ts:      ts,
+		})
+		return e
+	})
+	if err != nil {
+		return ""
+	}
+	return permalink
+}
+
+// buildPermalink は chat.getPermalink と同じ形式のパーマリンクを組み立てる。
+// 例: https://example.slack.com/archives/C01234ABCDE/p1234567890123456
+// スレッド返信の場合は ?thread_ts=...&cid=... を付与する。
+func buildPermalink(workspaceURL, channelID, ts, threadTS string) string {
+	link := strings.TrimSuffix(workspaceURL, "/") + "/archives/" + channelID + "/p" + strings.Replace(ts, ".", "", 1)
+	if threadTS != "" && threadTS != ts {
+		link += "?thread_ts=" + threadTS + "&cid=" + channelID
+	}
+	return link
+}
@@ -0,0 +1,246 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// apiCallCounter はモックサーバーへのAPI呼び出し回数をメソッド別に数える。
+type apiCallCounter struct {
+	usersInfo    atomic.Int64
+	getPermalink atomic.Int64
+	authTest     atomic.Int64
+}
+
+func (c *apiCallCounter) total() int64 {
+	return c.usersInfo.Load() + c.getPermalink.Load() + c.authTest.Load()
+}
+
+// newHistoryMockClient は messageCount 件（投稿者 userCount 人）の履歴を返すモックのクライアントを作成する。
+// withAuthTest が false の場合は auth.test を提供しない（パーマリンクは chat.getPermalink にフォールバック）。
+func newHistoryMockClient(tb testing.TB, messageCount, userCount int, withAuthTest bool, counter *apiCallCounter) *Client {
+	tb.Helper()
+	messages := make([]map[string]any, 0, messageCount)
+	for i := 0; i < messageCount; i++ {
+		messages = append(messages, map[string]any{
+			"user": fmt.Sprintf("U%08d", i%userCount),
+			"text": fmt.Sprintf("message %d", i),
+			"ts":   fmt.Sprintf("1700000000.%06d", i),
+		})
+	}
+
+	handlers := map[string]http.HandlerFunc{
+		"/conversations.history": func(w http.ResponseWriter, r *http.Request) {
+			jsonResponse(w, map[string]any{"ok": true, "messages": messages})
+		},
+		"/users.info": func(w http.ResponseWriter, r *http.Request) {
+			counter.usersInfo.Add(1)
+			r.ParseForm()
+			jsonResponse(w, map[string]any{
+				"ok":   true,
+				"user": map[string]any{"id": r.FormValue("user"), "name": "name-" + r.FormValue("user")},
+			})
+		},
+		"/chat.getPermalink": func(w http.ResponseWriter, r *http.Request) {
+			counter.getPermalink.Add(1)
+			jsonResponse(w, map[string]any{"ok": true, "permalink": "https://test.slack.com/archives/C1/p1"})
+		},
+	}
+	if withAuthTest {
+		handlers["/auth.test"] = func(w http.ResponseWriter, r *http.Request) {
+			counter.authTest.Add(1)
+			jsonResponse(w, map[string]any{"ok": true, "url": "https://test.slack.com/", "team_id": "T01234"})
+		}
+	}
+
+	_, api := newMockSlackServer(tb, handlers)
+	return NewClientWithAPI(api)
+}
+
+// --- U01: ユーザー名解決の重複排除とキャッシュ ---
+func TestClient_GetHistory_BatchedUserLookup(t *testing.T) {
+	var counter apiCallCounter
+	client := newHistoryMockClient(t, 100, 3, true, &counter)
+
+	result, err := client.GetHistory(context.Background(), "C01234ABCDE", HistoryOptions{Limit: 100})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Messages[4].UserName != "name-U00000001" {
+		t.Errorf("Messages[4].UserName = %q, want %q", result.Messages[4].UserName, "name-U00000001")
+	}
+	if got := counter.usersInfo.Load(); got != 3 {
+		t.Errorf("users.info calls = %d, want 3 (one per distinct user)", got)
+	}
+	if got := counter.getPermalink.Load(); got != 0 {
+		t.Errorf("chat.getPermalink calls = %d, want 0 (built locally)", got)
+	}
+	if got := counter.authTest.Load(); got != 1 {
+		t.Errorf("auth.test calls = %d, want 1", got)
+	}
+
+	// 2回目はキャッシュから解決される
+	if _, err := client.GetHistory(context.Background(), "C01234ABCDE", HistoryOptions{Limit: 100}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := counter.total(); got != 4 {
+		t.Errorf("total extra API calls after second fetch = %d, want 4", got)
+	}
+}
+
+// --- U02: auth.test 失敗時は chat.getPermalink にフォールバック ---
+func TestClient_GetHistory_PermalinkFallback(t *testing.T) {
+	var counter apiCallCounter
+	client := newHistoryMockClient(t, 2, 1, false, &counter)
+
+	result, err := client.GetHistory(context.Background(), "C01234ABCDE", HistoryOptions{Limit: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Messages[0].Permalink == "" {
+		t.Error("expected permalink from chat.getPermalink fallback")
+	}
+	if got := counter.getPermalink.Load(); got != 2 {
+		t.Errorf("chat.getPermalink calls = %d, want 2", got)
+	}
+}
+
+// --- U03: userCache の TTL ---
+func TestUserCache_TTL(t *testing.T) {
+	now := time.Now()
+	cache := newUserCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.set("U1", "alice")
+	if name, ok := cache.get("U1"); !ok || name != "alice" {
+		t.Errorf("get = %q, %v; want alice, true", name, ok)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.get("U1"); ok {
+		t.Error("expected entry to expire after TTL")
+	}
+}
+
+// --- U04: パーマリンクのローカル生成 ---
+func TestBuildPermalink(t *testing.T) {
+	tests := []struct {
+		name     string
+		ts       string
+		threadTS string
+		want     string
+	}{
+		{"top-level message", "1234567890.123456", "", "https://test.slack.com/archives/C01234ABCDE/p1234567890123456"},
+		{"thread parent", "1234567890.123456", "1234567890.123456", "https://test.slack.com/archives/C01234ABCDE/p1234567890123456"},
+		{"thread reply", "1234567890.654321", "1234567890.123456",
+			"https://test.slack.com/archives/C01234ABCDE/p1234567890654321?thread_ts=1234567890.123456&cid=C01234ABCDE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPermalink("https://test.slack.com/", "C01234ABCDE", tt.ts, tt.threadTS)
+			if got != tt.want {
+				t.Errorf("buildPermalink = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// --- U05: auth.test の失敗はキャッシュし続けず、同時の呼び出しは1回にまとめる ---
+func TestClient_FetchWorkspace_RetriesAfterFailure(t *testing.T) {
+	var calls atomic.Int64
+	var fail atomic.Bool
+	fail.Store(true)
+	release := make(chan struct{})
+	_, api := newMockSlackServer(t, map[string]http.HandlerFunc{
+		"/auth.test": func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if fail.Load() {
+				jsonResponse(w, map[string]any{"ok": false, "error": "invalid_auth"})
+				return
+			}
+			<-release
+			jsonResponse(w, map[string]any{"ok": true, "url": "https://test.slack.com/", "team_id": "T01234"})
+		},
+	})
+	client := NewClientWithAPI(api)
+	ctx := context.Background()
+
+	if got := client.workspaceTeamID(ctx); got != "" {
+		t.Fatalf("workspaceTeamID() = %q, want empty after failure", got)
+	}
+	// 再試行間隔の間は auth.test を呼ばない
+	client.workspaceTeamID(ctx)
+	if got := calls.Load(); got != 1 {
+		t.Errorf("auth.test calls = %d, want 1 within retry interval", got)
+	}
+
+	// 間隔が過ぎたら再試行し、同時の呼び出しは1回にまとめる
+	fail.Store(false)
+	client.workspaceMu.Lock()
+	client.workspaceFailedAt = time.Now().Add(-workspaceRetryInterval)
+	client.workspaceMu.Unlock()
+	results := make(chan string, 5)
+	for i := 0; i < 5; i++ {
+		go func() { results <- client.workspaceTeamID(ctx) }()
+	}
+	for calls.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	for i := 0; i < 5; i++ {
+		if got := <-results; got != "T01234" {
+			t.Errorf("workspaceTeamID() = %q, want T01234", got)
+		}
+	}
+	// 成功した結果はキャッシュする
+	if got := client.workspaceURL(ctx); got != "https://test.slack.com/" || calls.Load() != 2 {
+		t.Errorf("workspaceURL() = %q, auth.test calls = %d; want cached result and 2 calls", got, calls.Load())
+	}
+}
+
+// BenchmarkClient_GetHistory は100件取得時の追加API呼び出し数（api-calls/op）を計測する。
+// 旧実装はメッセージごとに users.info + chat.getPermalink を呼ぶため 200 calls/op。
+func BenchmarkClient_GetHistory(b *testing.B) {
+	for _, bc := range []struct {
+		name      string
+		fresh     bool // 毎回新しいクライアント（キャッシュなし）
+		authTest  bool
+		userCount int
+	}{
+		{"cold_cache_10_users", true, true, 10},
+		{"warm_cache_10_users", false, true, 10},
+		{"no_auth_test_fallback", true, false, 10},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var counter apiCallCounter
+			client := newHistoryMockClient(b, 100, bc.userCount, bc.authTest, &counter)
+			if !bc.fresh {
+				// キャッシュを温める
+				if _, err := client.GetHistory(context.Background(), "C01234ABCDE", HistoryOptions{Limit: 100}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			counter = apiCallCounter{}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if bc.fresh {
+					b.StopTimer()
+					client.users = newUserCache(userCacheTTL)
+					client.workspaceFetched = false
+					client.workspaceFailedAt = time.Time{}
+					b.StartTimer()
+				}
+				if _, err := client.GetHistory(context.Background(), "C01234ABCDE", HistoryOptions{Limit: 100}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(counter.total())/float64(b.N), "api-calls/op")
+		})
+	}
+}